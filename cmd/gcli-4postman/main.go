@@ -2,7 +2,9 @@ package main
 
 import (
 	"fmt"
+	"io"
 	"os"
+	"slices"
 	"strings"
 
 	"github.com/c-bata/go-prompt"
@@ -14,6 +16,7 @@ import (
 	"github.com/joakim-ribier/go-utils/pkg/genericsutil"
 	"github.com/joakim-ribier/go-utils/pkg/slicesutil"
 	"github.com/joakim-ribier/go-utils/pkg/stringsutil"
+	"github.com/mattn/go-isatty"
 )
 
 const logo = `
@@ -33,12 +36,19 @@ var (
 	context        *internal.Context
 	actions        []internal.PromptAction
 	promptCallback *internal.PromptCallback
+	nonInteractive bool
 
 	log logger.Logger
 )
 
+// the application options which have a value
+var argsWithValue = []string{"--home", "--mode", "--secret", "--log", "--script", "--output"}
+
+// the application options which have no value
+var argsWithoutValue = []string{"--yes"}
+
 func main() {
-	args := slicesutil.ToMap(os.Args[1:])
+	args, commands := parseArgs(os.Args[1:])
 	if arg, ok := args[string("--home")]; ok {
 		internal.GCLI_4POSTMAN_HOME = arg
 	}
@@ -51,13 +61,37 @@ func main() {
 	if arg, ok := args[string("--log")]; ok {
 		internal.FILE_LOG = arg
 	}
+	if arg, ok := args[string("--output")]; ok {
+		internal.OUTPUT_FORMAT = arg
+	}
+	if !internal.IsTextOutput() || !isatty.IsTerminal(os.Stdout.Fd()) {
+		prettyprint.DisableColors()
+	}
+	if !slices.Contains([]string{internal.OutputText, internal.OutputJson, internal.OutputNdJson}, internal.OUTPUT_FORMAT) {
+		print("ERROR", "unknown output format {%s} (text, json or ndjson)", internal.OUTPUT_FORMAT)
+		os.Exit(2)
+	}
 
 	log = logger.NewLogger(stringsutil.NewStringS(internal.FILE_LOG).OrElse("gcli-4postman.log"))
 
-	print("", logo)
-	print("", "MODE=%s SECURE=%s", prettyprint.FormatTextWithColor(strings.ToUpper(internal.APP_MODE), "INFO", false), prettyprint.FormatTextWithColor(secureMode(), "INFO", false))
-	print("", "$CLI-4Postman %s", prettyprint.FormatTextWithColor(internal.GCLI_4POSTMAN_HOME, "INFO", false))
-	print("", " ")
+	if arg, ok := args[string("--script")]; ok {
+		lines, err := readScript(arg)
+		if err != nil {
+			log.Error(err, "script cannot be read", "resource", arg)
+			print("ERROR", "unable to read the script %s", arg)
+			os.Exit(2)
+		}
+		commands = append(commands, lines...)
+	}
+	_, exec := args[string("exec")]
+	nonInteractive = exec || commands != nil
+
+	if !nonInteractive {
+		print("", logo)
+		print("", "MODE=%s SECURE=%s", prettyprint.FormatTextWithColor(strings.ToUpper(internal.APP_MODE), "INFO", false), prettyprint.FormatTextWithColor(secureMode(), "INFO", false))
+		print("", "$CLI-4Postman %s", prettyprint.FormatTextWithColor(internal.GCLI_4POSTMAN_HOME, "INFO", false))
+		print("", " ")
+	}
 
 	log.Info(logo, "GCLI_4POSTMAN_HOME", internal.GCLI_4POSTMAN_HOME, "mode", internal.APP_MODE, "secure", secureMode())
 
@@ -67,10 +101,15 @@ func main() {
 		print("WARN", "$CLI-4Postman is not a folder, please select a correct one before continuing...\n")
 		print("INFO", "declare the %s var in your home", prettyprint.FormatTextWithColor("$CLI-4Postman", "Y", false))
 		print("INFO", "or directly by adding argument %s\n", prettyprint.FormatTextWithColor("./go-cli-4Postman --home {folder}", "Y", false))
+		if nonInteractive {
+			os.Exit(2)
+		}
 		return
 	}
 
-	print("INFO", "Type %s for available commands...", prettyprint.FormatTextWithColor("help", "INFO", false))
+	if !nonInteractive {
+		print("INFO", "Type %s for available commands...", prettyprint.FormatTextWithColor("help", "INFO", false))
+	}
 
 	context = internal.NewContext(log, print)
 
@@ -78,11 +117,20 @@ func main() {
 		context.CMDsHistory = v
 	}
 
+	if v, err := ioutil.Load[internal.Settings](context.GetSettingsPath(), internal.SECRET); err == nil {
+		context.Settings = v
+	}
+
 	actions = append(actions,
 		promptactions.NewPromptLoadCollection(context),
 		promptactions.NewPromptSelectEnv(context),
+		promptactions.NewPromptGlobals(context),
 		promptactions.NewPromptExecuteRequest(context),
+		promptactions.NewPromptRunCollection(context),
 		promptactions.NewPromptDisplayCollection(context),
+		promptactions.NewPromptImport(context),
+		promptactions.NewPromptExport(context),
+		promptactions.NewPromptEdit(context),
 		promptactions.NewPromptPostman(context),
 		promptactions.NewPromptSettings(context),
 		promptactions.NewPromptExitApp(context),
@@ -90,6 +138,11 @@ func main() {
 
 	actions = append(actions, promptactions.NewPromptHelp(actions))
 
+	if nonInteractive {
+		_, yes := args[string("--yes")]
+		os.Exit(execute(commands, yes))
+	}
+
 	p := prompt.New(
 		promptExecutor,
 		promptCompleter,
@@ -206,15 +259,20 @@ func promptSuggest(d prompt.Document) []prompt.Suggest {
 				{Text: "load", Description: "[:l]oad a collection"},
 				{Text: "postman", Description: "synchronize data from [:P]ostman account (API Key)"},
 				{Text: "env", Description: "select the execution [:e]nvironnment"},
+				{Text: "globals", Description: "manage the [:g]lobal variables"},
 				{Text: "display", Description: "[:d]isplay the selected collection"},
 				{Text: "http", Description: "execute an [:h]ttp API request"},
+				{Text: "run", Description: "[:r]un the requests of the collection"},
+				{Text: "import", Description: "[:i]mport API requests as a local collection"},
+				{Text: "export", Description: "e[x]port the collection in the Postman format"},
+				{Text: "edit", Description: "[:c]reate, edit or delete the requests of the collection"},
 				{Text: "help", Description: "show help"},
 				{Text: "settings", Description: "application's [:s]ettings"},
 				{Text: "exit", Description: "[:q]uit the application (Bye)"},
 			}
 
 			return slicesutil.FilterT(suggests, func(s prompt.Suggest) bool {
-				return internal.APP_MODE == "admin" || (s.Text != "postman" && s.Text != "settings")
+				return internal.APP_MODE == "admin" || s.Text != "postman"
 			})
 
 		} else {
@@ -240,6 +298,87 @@ func promptExecutor(in string) {
 	LivePrefix = promptRefreshPrefix(false)
 }
 
+// parseArgs parses the application arguments {args}: the options and the commands which follow "exec".
+func parseArgs(args []string) (map[string]string, []string) {
+	options := map[string]string{}
+	var commands []string
+	for i := 0; i < len(args); i++ {
+		switch {
+		case args[i] == "exec":
+			options[args[i]] = ""
+			commands = []string{}
+			// the application options can also be placed after the commands
+			for i = i + 1; i < len(args); i++ {
+				switch {
+				case slices.Contains(argsWithValue, args[i]) && i+1 < len(args):
+					options[args[i]] = args[i+1]
+					i++
+				case slices.Contains(argsWithoutValue, args[i]):
+					options[args[i]] = ""
+				default:
+					commands = append(commands, args[i])
+				}
+			}
+			return options, commands
+		case slices.Contains(argsWithValue, args[i]) && i+1 < len(args):
+			options[args[i]] = args[i+1]
+			i++
+		default:
+			options[args[i]] = ""
+		}
+	}
+	return options, commands
+}
+
+// readScript reads the commands of the script file {path} (one per line).
+func readScript(path string) ([]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return strings.Split(strings.ReplaceAll(string(data), "\r\n", "\n"), "\n"), nil
+}
+
+// execute executes the {commands} through the prompt actions (non-interactive mode) and returns the exit code:
+// 0 on success, 1 if a check or a command has failed (set by the action, stops the execution)
+// and 2 if a command cannot be executed (unknown command or confirmation declined).
+// The confirmations are accepted if {yes} is true, declined otherwise.
+func execute(commands []string, yes bool) int {
+	for _, command := range commands {
+		command = strings.TrimSpace(command)
+		if command == "" || strings.HasPrefix(command, "#") {
+			continue
+		}
+
+		print("", "$ %s", command)
+		tab := stringsutil.Split(command, internal.SEP_CHARACTER, internal.ENCLOSE_CHARACTER)
+		if !slicesutil.ExistT(actions, func(pa internal.PromptAction) bool {
+			return slicesutil.Exist(pa.GetActionKeys(), tab[0])
+		}) {
+			print("ERROR", "unknown command {%s}", tab[0])
+			return 2
+		}
+
+		promptExecutor(command)
+
+		if promptCallback != nil {
+			callback := *promptCallback
+			promptCallback = nil
+			if !yes {
+				print("ERROR", "{%s} requires a confirmation, add --yes to confirm", callback.Text)
+				return 2
+			}
+			print("", "%s >> Yes", callback.Text)
+			callback.Callback([]string{"Yes"}, actions)
+		}
+
+		if internal.EXIT_CODE != 0 {
+			return internal.EXIT_CODE
+		}
+	}
+	return internal.EXIT_CODE
+}
+
 func print(level, text string, args ...any) {
 	prefix, suffix := "", ""
 	switch strings.ToLower(level) {
@@ -258,11 +397,17 @@ func print(level, text string, args ...any) {
 		prefix = "> "
 	}
 
+	// the messages must not be mixed with the structured output
+	var w io.Writer = os.Stdout
+	if !internal.IsTextOutput() {
+		w = os.Stderr
+	}
+
 	if strings.ToLower(level) != "debug" && text != "" {
 		if len(args) > 0 {
-			prettyprint.Print(prettyprint.SPrintInColor(fmt.Sprintf(prefix+text+suffix, args...), level, false))
+			prettyprint.Fprint(w, prettyprint.SPrintInColor(fmt.Sprintf(prefix+text+suffix, args...), level, false))
 		} else {
-			prettyprint.Print(prettyprint.SPrintInColor(prefix+text+suffix, level, false))
+			prettyprint.Fprint(w, prettyprint.SPrintInColor(prefix+text+suffix, level, false))
 		}
 	}
 }
@@ -2,10 +2,13 @@ package internal
 
 import (
 	"os"
+	"path/filepath"
+	"strings"
 
 	"github.com/gosimple/slug"
 	"github.com/joakim-ribier/gcli-4postman/internal/postman"
 	"github.com/joakim-ribier/gcli-4postman/pkg/logger"
+	"github.com/joakim-ribier/go-utils/pkg/slicesutil"
 )
 
 var GCLI_4POSTMAN_HOME = os.Getenv("GCLI_4POSTMAN_HOME")
@@ -17,6 +20,23 @@ var SEP_CHARACTER = " "
 var ENCLOSE_CHARACTER = "'"
 var MAX_CMD_HISTORISE = 50
 
+// OUTPUT_FORMAT is the output format of the responses and the listings: "text" (default), "json" or "ndjson".
+var OUTPUT_FORMAT = OutputText
+
+const (
+	OutputText   = "text"
+	OutputJson   = "json"
+	OutputNdJson = "ndjson"
+)
+
+// IsTextOutput returns {true} if the output format is the human readable one.
+func IsTextOutput() bool {
+	return OUTPUT_FORMAT != OutputJson && OUTPUT_FORMAT != OutputNdJson
+}
+
+// EXIT_CODE is the application exit code (non-interactive mode), set to 1 when a check or a command fails.
+var EXIT_CODE = 0
+
 type Context struct {
 	WorkspaceName  string
 	CollectionName string
@@ -27,6 +47,7 @@ type Context struct {
 
 	CollectionHistoryRequests postman.CollectionHistoryItemsLight
 	CMDsHistory               CMDHistories
+	Settings                  Settings
 
 	Log   logger.Logger
 	Print func(string, string, ...any)
@@ -50,6 +71,17 @@ func (c *Context) GetEnvName() string {
 	}
 }
 
+// UpdateEnv replaces the current env and its reference in the loaded envs by {env}.
+func (c *Context) UpdateEnv(env postman.Env) {
+	c.Env = &env
+	c.Envs = slicesutil.TransformT[postman.Env, postman.Env](c.Envs, func(e postman.Env) (*postman.Env, error) {
+		if e.GetName() == env.GetName() {
+			return &env, nil
+		}
+		return &e, nil
+	})
+}
+
 func (c *Context) Clean() {
 	c.WorkspaceName = ""
 	c.CollectionName = ""
@@ -74,10 +106,31 @@ func (c *Context) GetEnvPath(env postman.Env) string {
 	return GetHomeWorkspaceFilePath(c.WorkspaceName, slug.Make(env.GetName())+".env.json")
 }
 
+// GetGlobalsPath returns the path of the globals file of the current workspace (of the home if {home} is true).
+func (c *Context) GetGlobalsPath(home bool) string {
+	if home {
+		return GetHomeFilePath("globals.json")
+	}
+	return GetHomeWorkspaceFilePath(c.WorkspaceName, "globals.json")
+}
+
+// GetCookiesPath returns the path of the cookies file of the current workspace and environment.
+func (c *Context) GetCookiesPath() string {
+	envName := c.GetEnvName()
+	if envName == "" {
+		envName = "no-env"
+	}
+	return GetHomeWorkspaceFilePath(c.WorkspaceName, envName+".cookies.json")
+}
+
 func (c *Context) GetCMDHistoryPath() string {
 	return GetHomeFilePath("gcli-4postman_cmd.json")
 }
 
+func (c *Context) GetSettingsPath() string {
+	return GetHomeFilePath("gcli-4postman_settings.json")
+}
+
 func (c *Context) GetWorkspacePath() string {
 	return GetHomeWorkspacePath(c.WorkspaceName)
 }
@@ -93,3 +146,15 @@ func GetHomeWorkspaceFilePath(workspaceName, file string) string {
 func GetHomeFilePath(file string) string {
 	return GCLI_4POSTMAN_HOME + "/" + file
 }
+
+// ExpandUserHome replaces the leading "~/" of the {path} by the user home directory (the shell does not expand it in the prompt).
+func ExpandUserHome(path string) string {
+	if !strings.HasPrefix(path, "~/") {
+		return path
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return path
+	}
+	return filepath.Join(home, path[2:])
+}
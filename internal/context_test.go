@@ -0,0 +1,19 @@
+package internal
+
+import "testing"
+
+func TestExpandUserHome(t *testing.T) {
+	t.Setenv("HOME", "/home/user")
+
+	tests := map[string]string{
+		"~/Downloads/c.json": "/home/user/Downloads/c.json",
+		"/tmp/~/c.json":      "/tmp/~/c.json",
+		"~user/c.json":       "~user/c.json",
+		"c.json":             "c.json",
+	}
+	for path, want := range tests {
+		if got := ExpandUserHome(path); got != want {
+			t.Errorf("ExpandUserHome(%q) = %q, want %q", path, got, want)
+		}
+	}
+}
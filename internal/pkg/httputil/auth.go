@@ -0,0 +1,296 @@
+package httputil
+
+import (
+	"crypto/hmac"
+	"crypto/md5"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/joakim-ribier/gcli-4postman/internal/postman"
+	"github.com/joakim-ribier/go-utils/pkg/httpsutil"
+)
+
+// setAuth applies the {auth} type on the request {r} (must be called once the method, headers and body are set).
+func setAuth(client *http.Client, r *httpsutil.HttpRequest, auth postman.Auth, body string, env *postman.Env, params []postman.Param, scope postman.Variables) error {
+	get := func(key string) string {
+		return auth.Get(key, env, params, scope)
+	}
+	getOrElse := func(key, orIsEmpty string) string {
+		return auth.GetOrElse(key, orIsEmpty, env, params, scope)
+	}
+
+	switch strings.ToLower(auth.Type) {
+	case postman.AuthBasic:
+		r.SetBasicAuth(get("username"), get("password"))
+	case postman.AuthBearer:
+		r.Header("Authorization", "Bearer "+get("token"))
+	case postman.AuthApiKey:
+		if getOrElse("in", "header") == "query" {
+			addQueryParam(r.Req, get("key"), get("value"))
+		} else {
+			r.Header(get("key"), get("value"))
+		}
+	case postman.AuthOAuth2:
+		if getOrElse("addTokenTo", "header") == "queryParams" {
+			addQueryParam(r.Req, "access_token", get("accessToken"))
+		} else {
+			r.Header("Authorization", strings.TrimSpace(getOrElse("headerPrefix", "Bearer")+" "+get("accessToken")))
+		}
+	case postman.AuthDigest:
+		return setDigestAuth(client, r.Req, body, get)
+	case postman.AuthAwsV4:
+		setAwsV4Auth(r.Req, body, time.Now().UTC(), get)
+	}
+	return nil
+}
+
+func addQueryParam(req *http.Request, key, value string) {
+	query := req.URL.Query()
+	query.Add(key, value)
+	req.URL.RawQuery = query.Encode()
+}
+
+// setDigestAuth computes the digest (RFC 7616) "Authorization" header,
+// if the {realm} or the {nonce} are not provided a first request is sent to get the server challenge.
+func setDigestAuth(client *http.Client, req *http.Request, body string, get func(string) string) error {
+	challenge := map[string]string{
+		"realm":     get("realm"),
+		"nonce":     get("nonce"),
+		"algorithm": get("algorithm"),
+		"qop":       get("qop"),
+		"opaque":    get("opaque"),
+	}
+
+	if challenge["realm"] == "" || challenge["nonce"] == "" {
+		values, err := getDigestChallenge(client, req, body)
+		if err != nil {
+			return err
+		}
+		for key, value := range values {
+			if challenge[key] == "" {
+				challenge[key] = value
+			}
+		}
+	}
+
+	var h func() hash.Hash = md5.New
+	algorithm := strings.ToUpper(challenge["algorithm"])
+	if strings.HasPrefix(algorithm, "SHA-256") {
+		h = sha256.New
+	}
+	hashS := func(s string) string {
+		hh := h()
+		hh.Write([]byte(s))
+		return hex.EncodeToString(hh.Sum(nil))
+	}
+
+	nc := get("nc")
+	if nc == "" {
+		nc = "00000001"
+	}
+	cnonce := get("cnonce")
+	if cnonce == "" {
+		b := make([]byte, 8)
+		rand.Read(b)
+		cnonce = hex.EncodeToString(b)
+	}
+
+	ha1 := hashS(get("username") + ":" + challenge["realm"] + ":" + get("password"))
+	if strings.HasSuffix(algorithm, "-SESS") {
+		ha1 = hashS(ha1 + ":" + challenge["nonce"] + ":" + cnonce)
+	}
+
+	qop := ""
+	for _, value := range strings.Split(challenge["qop"], ",") {
+		if v := strings.TrimSpace(value); v == "auth" || (v == "auth-int" && qop == "") {
+			qop = v
+		}
+	}
+
+	ha2 := hashS(req.Method + ":" + req.URL.RequestURI())
+	if qop == "auth-int" {
+		ha2 = hashS(req.Method + ":" + req.URL.RequestURI() + ":" + hashS(body))
+	}
+
+	var response string
+	if qop == "" {
+		response = hashS(ha1 + ":" + challenge["nonce"] + ":" + ha2)
+	} else {
+		response = hashS(ha1 + ":" + challenge["nonce"] + ":" + nc + ":" + cnonce + ":" + qop + ":" + ha2)
+	}
+
+	header := fmt.Sprintf(`Digest username="%s", realm="%s", nonce="%s", uri="%s", response="%s"`,
+		get("username"), challenge["realm"], challenge["nonce"], req.URL.RequestURI(), response)
+	if challenge["algorithm"] != "" {
+		header += ", algorithm=" + challenge["algorithm"]
+	}
+	if qop != "" {
+		header += fmt.Sprintf(`, qop=%s, nc=%s, cnonce="%s"`, qop, nc, cnonce)
+	}
+	if challenge["opaque"] != "" {
+		header += fmt.Sprintf(`, opaque="%s"`, challenge["opaque"])
+	}
+
+	req.Header.Set("Authorization", header)
+	return nil
+}
+
+// getDigestChallenge sends the request without credentials and parses the "WWW-Authenticate" header response.
+func getDigestChallenge(client *http.Client, req *http.Request, body string) (map[string]string, error) {
+	challengeReq := req.Clone(req.Context())
+	challengeReq.Body = http.NoBody
+	if body != "" {
+		challengeReq.Body = io.NopCloser(strings.NewReader(body))
+	}
+
+	resp, err := client.Do(challengeReq)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	header := resp.Header.Get("WWW-Authenticate")
+	if resp.StatusCode != http.StatusUnauthorized || !strings.HasPrefix(strings.ToLower(header), "digest ") {
+		return nil, errors.New("digest authentication challenge not found in the server response")
+	}
+
+	values := map[string]string{}
+	for _, part := range splitChallenge(header[len("digest "):]) {
+		if key, value, found := strings.Cut(strings.TrimSpace(part), "="); found {
+			values[strings.ToLower(key)] = strings.Trim(value, `"`)
+		}
+	}
+	return values, nil
+}
+
+// splitChallenge splits the challenge values on the "," not enclosed by quotes.
+func splitChallenge(value string) []string {
+	var out []string
+	quote := false
+	start := 0
+	for i, r := range value {
+		switch r {
+		case '"':
+			quote = !quote
+		case ',':
+			if !quote {
+				out = append(out, value[start:i])
+				start = i + 1
+			}
+		}
+	}
+	return append(out, value[start:])
+}
+
+// setAwsV4Auth signs the request with the AWS Signature Version 4.
+func setAwsV4Auth(req *http.Request, body string, now time.Time, get func(string) string) {
+	amzDate := now.Format("20060102T150405Z")
+	date := now.Format("20060102")
+	region := get("region")
+	if region == "" {
+		region = "us-east-1"
+	}
+	service := get("service")
+	payloadHash := sha256Hex(body)
+
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+	if token := get("sessionToken"); token != "" {
+		req.Header.Set("X-Amz-Security-Token", token)
+	}
+
+	headers := map[string]string{"host": req.URL.Host}
+	for key, values := range req.Header {
+		if lower := strings.ToLower(key); strings.HasPrefix(lower, "x-amz-") || lower == "content-type" {
+			headers[lower] = strings.TrimSpace(strings.Join(values, ","))
+		}
+	}
+	var signedHeaders []string
+	for key := range headers {
+		signedHeaders = append(signedHeaders, key)
+	}
+	sort.Strings(signedHeaders)
+
+	var canonicalHeaders strings.Builder
+	for _, key := range signedHeaders {
+		canonicalHeaders.WriteString(key + ":" + headers[key] + "\n")
+	}
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		canonicalURI(req.URL),
+		canonicalQuery(req.URL),
+		canonicalHeaders.String(),
+		strings.Join(signedHeaders, ";"),
+		payloadHash,
+	}, "\n")
+
+	credentialScope := strings.Join([]string{date, region, service, "aws4_request"}, "/")
+	stringToSign := strings.Join([]string{
+		"AWS4-HMAC-SHA256",
+		amzDate,
+		credentialScope,
+		sha256Hex(canonicalRequest),
+	}, "\n")
+
+	signingKey := hmacSHA256([]byte("AWS4"+get("secretKey")), date)
+	signingKey = hmacSHA256(signingKey, region)
+	signingKey = hmacSHA256(signingKey, service)
+	signingKey = hmacSHA256(signingKey, "aws4_request")
+
+	req.Header.Set("Authorization", fmt.Sprintf(
+		"AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		get("accessKey"), credentialScope, strings.Join(signedHeaders, ";"),
+		hex.EncodeToString(hmacSHA256(signingKey, stringToSign))))
+}
+
+func canonicalURI(u *url.URL) string {
+	if path := u.EscapedPath(); path != "" {
+		return path
+	}
+	return "/"
+}
+
+func canonicalQuery(u *url.URL) string {
+	query := u.Query()
+	var keys []string
+	for key := range query {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	escape := func(s string) string {
+		return strings.ReplaceAll(url.QueryEscape(s), "+", "%20")
+	}
+
+	var out []string
+	for _, key := range keys {
+		values := query[key]
+		sort.Strings(values)
+		for _, value := range values {
+			out = append(out, escape(key)+"="+escape(value))
+		}
+	}
+	return strings.Join(out, "&")
+}
+
+func sha256Hex(s string) string {
+	h := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(h[:])
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(data))
+	return h.Sum(nil)
+}
@@ -0,0 +1,79 @@
+package httputil
+
+import (
+	"bytes"
+	"fmt"
+	"mime/multipart"
+	"net/textproto"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/joakim-ribier/gcli-4postman/internal/postman"
+	"github.com/joakim-ribier/go-utils/pkg/stringsutil"
+)
+
+// buildBody builds the payload and the "Content-Type" of the request body depending on its mode.
+func buildBody(body postman.Body, env *postman.Env, params []postman.Param, scope postman.Variables) (string, string, error) {
+	switch body.GetMode() {
+	case postman.BodyModeFormData:
+		return buildMultipartBody(body.FormData.Enabled(), func(raw string) string {
+			return postman.Resolve(raw, env, params, scope)
+		})
+	case postman.BodyModeFile:
+		if body.File == nil || body.File.Src == "" {
+			return "", body.GetContentType(), nil
+		}
+		data, err := os.ReadFile(postman.Resolve(body.File.Src, env, params, scope))
+		if err != nil {
+			return "", "", err
+		}
+		return string(data), body.GetContentType(), nil
+	default:
+		return body.Get(env, params, scope), body.GetContentType(), nil
+	}
+}
+
+// buildMultipartBody builds the "multipart/form-data" payload (text and file parts).
+func buildMultipartBody(fields postman.BodyParams, resolve func(string) string) (string, string, error) {
+	buffer := &bytes.Buffer{}
+	writer := multipart.NewWriter(buffer)
+
+	for _, field := range fields {
+		if field.IsFile() {
+			for _, src := range field.GetSrc() {
+				path := resolve(src)
+				data, err := os.ReadFile(path)
+				if err != nil {
+					return "", "", err
+				}
+
+				header := make(textproto.MIMEHeader)
+				header.Set("Content-Disposition", fmt.Sprintf(`form-data; name="%s"; filename="%s"`, escapeQuotes(resolve(field.Key)), escapeQuotes(filepath.Base(path))))
+				header.Set("Content-Type", stringsutil.OrElse(field.ContentType, "application/octet-stream"))
+				part, err := writer.CreatePart(header)
+				if err != nil {
+					return "", "", err
+				}
+				if _, err := part.Write(data); err != nil {
+					return "", "", err
+				}
+			}
+		} else {
+			if err := writer.WriteField(resolve(field.Key), resolve(field.Value)); err != nil {
+				return "", "", err
+			}
+		}
+	}
+
+	if err := writer.Close(); err != nil {
+		return "", "", err
+	}
+	return buffer.String(), writer.FormDataContentType(), nil
+}
+
+var quoteEscaper = strings.NewReplacer("\\", "\\\\", `"`, "\\\"")
+
+func escapeQuotes(s string) string {
+	return quoteEscaper.Replace(s)
+}
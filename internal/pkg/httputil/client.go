@@ -0,0 +1,78 @@
+package httputil
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"net/http"
+	"net/url"
+	"os"
+	"time"
+)
+
+const DefaultTimeout = 15
+
+// Options are the HTTP client options (timeout in seconds, redirects, proxy and TLS).
+type Options struct {
+	Timeout     int
+	NoRedirects bool
+	Proxy       string
+	Insecure    bool
+	CACert      string
+	ClientCert  string
+	ClientKey   string
+
+	Jar http.CookieJar `json:"-"`
+}
+
+// NewClient builds the HTTP client from the {options}.
+func NewClient(options Options) (*http.Client, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+
+	if options.Proxy != "" {
+		proxy, err := url.Parse(options.Proxy)
+		if err != nil {
+			return nil, err
+		}
+		transport.Proxy = http.ProxyURL(proxy)
+	}
+
+	tlsConfig := &tls.Config{InsecureSkipVerify: options.Insecure}
+	if options.CACert != "" {
+		pem, err := os.ReadFile(options.CACert)
+		if err != nil {
+			return nil, err
+		}
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, errors.New("no valid certificate found in the CA bundle " + options.CACert)
+		}
+		tlsConfig.RootCAs = pool
+	}
+	if options.ClientCert != "" {
+		cert, err := tls.LoadX509KeyPair(options.ClientCert, options.ClientKey)
+		if err != nil {
+			return nil, err
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+	transport.TLSClientConfig = tlsConfig
+
+	client := &http.Client{
+		Jar:       options.Jar,
+		Transport: transport,
+		Timeout:   time.Duration(DefaultTimeout) * time.Second,
+	}
+	if options.Timeout > 0 {
+		client.Timeout = time.Duration(options.Timeout) * time.Second
+	}
+	if options.NoRedirects {
+		client.CheckRedirect = func(req *http.Request, via []*http.Request) error {
+			return http.ErrUseLastResponse
+		}
+	}
+	return client, nil
+}
@@ -0,0 +1,86 @@
+package httputil
+
+import (
+	"net/http"
+	"net/http/cookiejar"
+	"net/url"
+	"time"
+
+	"github.com/joakim-ribier/go-utils/pkg/slicesutil"
+)
+
+type Cookies []Cookie
+
+// Cookie is a cookie received from the {Url}.
+type Cookie struct {
+	Url string
+	http.Cookie
+}
+
+// CookieJar is an {http.CookieJar} which keeps the received cookies to be able to persist them.
+type CookieJar struct {
+	jar     *cookiejar.Jar
+	cookies Cookies
+}
+
+// NewCookieJar builds a cookie jar initialized with the (not expired) {cookies}.
+func NewCookieJar(cookies Cookies) *CookieJar {
+	jar, _ := cookiejar.New(nil)
+	cookieJar := &CookieJar{jar: jar, cookies: Cookies{}}
+	for _, cookie := range cookies.NotExpired() {
+		if u, err := url.Parse(cookie.Url); err == nil {
+			c := cookie.Cookie
+			cookieJar.SetCookies(u, []*http.Cookie{&c})
+		}
+	}
+	return cookieJar
+}
+
+// SetCookies implements the {http.CookieJar} interface.
+func (j *CookieJar) SetCookies(u *url.URL, cookies []*http.Cookie) {
+	j.jar.SetCookies(u, cookies)
+	for _, cookie := range cookies {
+		c := Cookie{Url: u.Scheme + "://" + u.Host + u.Path, Cookie: *cookie}
+		if c.MaxAge > 0 {
+			// keep the absolute expiration date to be able to persist it
+			c.Expires = time.Now().Add(time.Duration(c.MaxAge) * time.Second)
+			c.MaxAge = 0
+		}
+		j.cookies = slicesutil.AddOrReplaceT(j.cookies, c, func(el Cookie) bool {
+			return el.GetDomain() == c.GetDomain() && el.Path == c.Path && el.Name == c.Name
+		})
+	}
+}
+
+// Cookies implements the {http.CookieJar} interface.
+func (j *CookieJar) Cookies(u *url.URL) []*http.Cookie {
+	return j.jar.Cookies(u)
+}
+
+// GetCookies returns the (not expired) cookies of the jar.
+func (j *CookieJar) GetCookies() Cookies {
+	return j.cookies.NotExpired()
+}
+
+// GetDomain returns the cookie domain or the host of the url which has sent it.
+func (c Cookie) GetDomain() string {
+	if c.Domain != "" {
+		return c.Domain
+	}
+	if u, err := url.Parse(c.Url); err == nil {
+		return u.Hostname()
+	}
+	return c.Url
+}
+
+// IsExpired returns {true} if the cookie is expired (or deleted by the server).
+func (c Cookie) IsExpired() bool {
+	return c.MaxAge < 0 || (!c.Expires.IsZero() && c.Expires.Before(time.Now()))
+}
+
+// NotExpired returns the cookies which are not expired.
+func (c Cookies) NotExpired() Cookies {
+	return slicesutil.FilterT(c, func(cookie Cookie) bool {
+		return !cookie.IsExpired()
+	})
+}
@@ -0,0 +1,395 @@
+package httputil
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/joakim-ribier/gcli-4postman/internal/postman"
+	"github.com/joakim-ribier/go-utils/pkg/httpsutil"
+)
+
+// curlOptionsWithValue are the curl options which have a value but are not used to build the request.
+var curlOptionsWithValue = []string{
+	"-o", "--output", "-m", "--max-time", "--connect-timeout", "-x", "--proxy", "--cacert", "--cert", "-E", "--key",
+	"-w", "--write-out", "--retry", "-c", "--cookie-jar", "--limit-rate", "-T", "--upload-file", "--resolve",
+}
+
+// ParseCurl parses the curl command line {args} (the "curl" program can be omitted) into a request item:
+// the method, the headers, the body (-d, --data-*, -F) and the basic auth (-u).
+func ParseCurl(args []string) (*postman.Item, error) {
+	if len(args) > 0 && args[0] == "curl" {
+		args = args[1:]
+	}
+
+	var (
+		method, rawUrl string
+		header         = postman.Headers{}
+		data           []string
+		form           postman.BodyParams
+		auth           *postman.Auth
+		get, head      bool
+	)
+
+	// the value of the "--option=value" form
+	var inline *string
+	next := func(i *int) (string, error) {
+		if inline != nil {
+			return *inline, nil
+		}
+		if *i+1 >= len(args) {
+			return "", fmt.Errorf("curl option {%s} requires a value", args[*i])
+		}
+		*i++
+		return args[*i], nil
+	}
+
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		// "-XPOST" and "--request=POST" forms
+		if value, found := strings.CutPrefix(arg, "-X"); found && value != "" && !strings.HasPrefix(arg, "--") {
+			method = value
+			continue
+		}
+		inline = nil
+		if key, value, found := strings.Cut(arg, "="); found && strings.HasPrefix(arg, "--") {
+			arg, inline = key, &value
+		}
+
+		var err error
+		var value string
+		switch arg {
+		case "-X", "--request":
+			method, err = next(&i)
+		case "-H", "--header":
+			if value, err = next(&i); err == nil {
+				key, v, _ := strings.Cut(value, ":")
+				header = append(header, postman.Header{Key: strings.TrimSpace(key), Value: strings.TrimSpace(v)})
+			}
+		case "-A", "--user-agent":
+			if value, err = next(&i); err == nil {
+				header = append(header, postman.Header{Key: "User-Agent", Value: value})
+			}
+		case "-e", "--referer":
+			if value, err = next(&i); err == nil {
+				header = append(header, postman.Header{Key: "Referer", Value: value})
+			}
+		case "-b", "--cookie":
+			if value, err = next(&i); err == nil {
+				header = append(header, postman.Header{Key: "Cookie", Value: value})
+			}
+		case "-d", "--data", "--data-raw", "--data-binary", "--data-ascii", "--json":
+			if value, err = next(&i); err == nil {
+				data = append(data, value)
+			}
+			if arg == "--json" {
+				header = append(header, postman.Header{Key: "Content-Type", Value: "application/json"})
+			}
+		case "--data-urlencode":
+			if value, err = next(&i); err == nil {
+				key, v, found := strings.Cut(value, "=")
+				if found {
+					data = append(data, key+"="+url.QueryEscape(v))
+				} else {
+					data = append(data, url.QueryEscape(value))
+				}
+			}
+		case "-F", "--form", "--form-string":
+			if value, err = next(&i); err == nil {
+				key, v, _ := strings.Cut(value, "=")
+				field := postman.BodyParam{Key: key, Value: v}
+				if src, isFile := strings.CutPrefix(v, "@"); isFile && arg != "--form-string" {
+					src, contentType, _ := strings.Cut(src, ";type=")
+					field = postman.BodyParam{Key: key, Type: "file", Src: src, ContentType: contentType}
+				}
+				form = append(form, field)
+			}
+		case "-u", "--user":
+			if value, err = next(&i); err == nil {
+				username, password, _ := strings.Cut(value, ":")
+				auth = &postman.Auth{Type: postman.AuthBasic, Basic: postman.AuthValues{
+					{Key: "username", Value: username, Type: "string"},
+					{Key: "password", Value: password, Type: "string"},
+				}}
+			}
+		case "--url":
+			rawUrl, err = next(&i)
+		case "-G", "--get":
+			get = true
+		case "-I", "--head":
+			head = true
+		case "--compressed":
+			// the HTTP client asks for (and decompresses) gzip responses by itself
+		default:
+			switch {
+			case slices.Contains(curlOptionsWithValue, arg):
+				_, err = next(&i)
+			case strings.HasPrefix(arg, "-"):
+				// the other options do not change the request (-k, -L, -s, -v, ...)
+			case rawUrl == "":
+				rawUrl = arg
+			}
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if rawUrl == "" {
+		return nil, errors.New("the curl command does not contain an url")
+	}
+	if !strings.Contains(rawUrl, "://") {
+		rawUrl = "http://" + rawUrl
+	}
+
+	request := postman.Request{Header: header}
+	switch {
+	case get && len(data) > 0:
+		separator := "?"
+		if strings.Contains(rawUrl, "?") {
+			separator = "&"
+		}
+		rawUrl += separator + strings.Join(data, "&")
+	case len(form) > 0:
+		request.Body = postman.Body{Mode: postman.BodyModeFormData, FormData: form}
+	case len(data) > 0:
+		request.Body = newCurlBody(strings.Join(data, "&"), header)
+	}
+	request.Url = postman.NewUrl(rawUrl)
+
+	switch {
+	case method != "":
+		request.Method = strings.ToUpper(method)
+	case head:
+		request.Method = "HEAD"
+	case !get && (len(data) > 0 || len(form) > 0):
+		request.Method = "POST"
+	default:
+		request.Method = "GET"
+	}
+	if auth != nil {
+		request.Auth = *auth
+	}
+
+	return &postman.Item{Name: getCurlName(request.Url), Request: request}, nil
+}
+
+// newCurlBody builds the raw body (JSON, XML or text) or the urlencoded one from the {data} (and the "Content-Type" header).
+func newCurlBody(data string, header postman.Headers) postman.Body {
+	var contentType string
+	for _, h := range header {
+		if strings.EqualFold(h.Key, "Content-Type") {
+			contentType = strings.ToLower(h.Value)
+		}
+	}
+
+	isForm := strings.Contains(contentType, "form-urlencoded")
+	if contentType == "" && !strings.HasPrefix(strings.TrimSpace(data), "{") && !strings.HasPrefix(strings.TrimSpace(data), "[") {
+		// curl default content type
+		values, err := url.ParseQuery(data)
+		isForm = err == nil && len(values) > 0 && strings.Contains(data, "=")
+	}
+	if isForm {
+		body := postman.Body{Mode: postman.BodyModeUrlEncoded}
+		for _, pair := range strings.Split(data, "&") {
+			key, value, _ := strings.Cut(pair, "=")
+			key, _ = url.QueryUnescape(key)
+			value, _ = url.QueryUnescape(value)
+			body.UrlEncoded = append(body.UrlEncoded, postman.BodyParam{Key: key, Value: value})
+		}
+		return body
+	}
+
+	body := postman.Body{Mode: postman.BodyModeRaw, Raw: data, Options: &postman.BodyOptions{}}
+	switch {
+	case strings.Contains(contentType, "xml"):
+		body.Options.Raw.Language = "xml"
+	case strings.Contains(contentType, "json"), contentType == "":
+		body.Options.Raw.Language = "json"
+	default:
+		body.Options.Raw.Language = "text"
+	}
+	return body
+}
+
+// getCurlName returns the request name from the last segment of the url path (the host otherwise).
+func getCurlName(u postman.Url) string {
+	if len(u.Path) > 0 {
+		return u.Path[len(u.Path)-1]
+	}
+	if out, err := url.Parse(u.Raw); err == nil && out.Host != "" {
+		return out.Host
+	}
+	return "request"
+}
+
+// ToCurl builds the curl command of the {item} API request with the values of the provided context (env and params).
+func ToCurl(item postman.Item, env *postman.Env, params []postman.Param) (string, error) {
+	mode := item.Request.Body.GetMode()
+	isFormData := mode == postman.BodyModeFormData
+
+	// the form-data and file bodies are read by curl
+	body, contentType := "", item.Request.Body.GetContentType()
+	if !isFormData && mode != postman.BodyModeFile {
+		var err error
+		if body, contentType, err = buildBody(item.Request.Body, env, params, item.Scope); err != nil {
+			return "", err
+		}
+	}
+	hasFile := mode == postman.BodyModeFile && item.Request.Body.File != nil && item.Request.Body.File.Src != ""
+
+	r, err := httpsutil.NewHttpRequest(item.Request.Url.Get(env, params, item.Scope), body)
+	if err != nil {
+		return "", err
+	}
+	r.Method(item.Request.Method)
+	if body != "" || hasFile {
+		r.Header("Content-Type", contentType)
+	}
+	r.Headers(item.Request.Header.Get(env, params, item.Scope))
+	if isFormData {
+		// the multipart body (and its boundary) is built by curl
+		r.Req.Header.Del("Content-Type")
+	}
+
+	args := []string{"curl", "-X", r.Req.Method}
+	if strings.EqualFold(item.Request.Auth.Type, postman.AuthDigest) {
+		// the digest needs the server challenge
+		get := func(key string) string { return item.Request.Auth.Get(key, env, params, item.Scope) }
+		args = append(args, "--digest", "-u", quote(get("username")+":"+get("password")))
+	} else if err := setAuth(nil, r, item.Request.Auth, body, env, params, item.Scope); err != nil {
+		return "", err
+	}
+	args = append(args, quote(r.Req.URL.String()))
+
+	keys := make([]string, 0, len(r.Req.Header))
+	for key := range r.Req.Header {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		for _, value := range r.Req.Header.Values(key) {
+			args = append(args, "-H", quote(key+": "+value))
+		}
+	}
+
+	switch {
+	case isFormData:
+		resolve := func(raw string) string { return postman.Resolve(raw, env, params, item.Scope) }
+		for _, field := range item.Request.Body.FormData.Enabled() {
+			if field.IsFile() {
+				for _, src := range field.GetSrc() {
+					args = append(args, "-F", quote(resolve(field.Key)+"=@"+resolve(src)))
+				}
+			} else {
+				args = append(args, "-F", quote(resolve(field.Key)+"="+resolve(field.Value)))
+			}
+		}
+	case hasFile:
+		args = append(args, "--data-binary", quote("@"+postman.Resolve(item.Request.Body.File.Src, env, params, item.Scope)))
+	case body != "":
+		args = append(args, "--data-raw", quote(body))
+	}
+
+	return strings.Join(args, " "), nil
+}
+
+// quote quotes the {value} for a POSIX shell (if needed).
+func quote(value string) string {
+	if value != "" && strings.IndexFunc(value, func(r rune) bool {
+		return !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || strings.ContainsRune("-_./:@=,+%", r))
+	}) == -1 {
+		return value
+	}
+	return "'" + strings.ReplaceAll(value, "'", `'\''`) + "'"
+}
+
+// SplitCurl splits the curl command {line} into arguments as a POSIX shell does
+// (single and double quotes, $'...' strings, escaped characters and line continuations).
+func SplitCurl(line string) ([]string, error) {
+	var (
+		args    []string
+		current strings.Builder
+		inArg   bool
+		quoted  rune
+	)
+	runes := []rune(line)
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		switch {
+		case quoted == '\'':
+			if r == '\'' {
+				quoted = 0
+			} else {
+				current.WriteRune(r)
+			}
+		case quoted == '$':
+			// ANSI-C quoting
+			if r == '\'' {
+				quoted = 0
+			} else if r == '\\' && i+1 < len(runes) {
+				i++
+				current.WriteString(unescape(runes[i]))
+			} else {
+				current.WriteRune(r)
+			}
+		case quoted == '"':
+			if r == '"' {
+				quoted = 0
+			} else if r == '\\' && i+1 < len(runes) && strings.ContainsRune("\"\\$`\n", runes[i+1]) {
+				i++
+				if runes[i] != '\n' {
+					current.WriteRune(runes[i])
+				}
+			} else {
+				current.WriteRune(r)
+			}
+		case r == '\\' && i+1 < len(runes):
+			i++
+			if runes[i] != '\n' && runes[i] != '\r' {
+				current.WriteRune(runes[i])
+				inArg = true
+			} else if runes[i] == '\r' && i+1 < len(runes) && runes[i+1] == '\n' {
+				i++
+			}
+		case r == '\'' || r == '"':
+			quoted, inArg = r, true
+		case r == '$' && i+1 < len(runes) && runes[i+1] == '\'':
+			quoted, inArg = '$', true
+			i++
+		case r == ' ' || r == '\t' || r == '\n' || r == '\r':
+			if inArg {
+				args = append(args, current.String())
+				current.Reset()
+				inArg = false
+			}
+		default:
+			current.WriteRune(r)
+			inArg = true
+		}
+	}
+	if quoted != 0 {
+		return nil, errors.New("the curl command contains an unclosed quote")
+	}
+	if inArg {
+		args = append(args, current.String())
+	}
+	return args, nil
+}
+
+// unescape returns the value of the ANSI-C escaped character {r}.
+func unescape(r rune) string {
+	switch r {
+	case 'n':
+		return "\n"
+	case 't':
+		return "\t"
+	case 'r':
+		return "\r"
+	default:
+		return string(r)
+	}
+}
@@ -1,24 +1,89 @@
 package httputil
 
 import (
+	"io"
+	"net/http"
+	"time"
+
 	"github.com/joakim-ribier/gcli-4postman/internal/postman"
 	"github.com/joakim-ribier/go-utils/pkg/httpsutil"
 )
 
-// Call executes collection {item} API request on a specific environment and returns the {httpsutil.HttpResponse}.
-func Call(item postman.Item, env *postman.Env, params []postman.Param) (*httpsutil.HttpResponse, error) {
-	r, err := httpsutil.NewHttpRequest(item.Request.Url.Get(env, params), item.Request.Body.Get(env, params))
+// HttpResponse is the {httpsutil.HttpResponse} completed with the HTTP exchange details.
+type HttpResponse struct {
+	httpsutil.HttpResponse
+
+	Url           string // final url (after redirects)
+	Proto         string
+	Header        http.Header
+	RequestHeader http.Header
+}
+
+// Call executes collection {item} API request on a specific environment with the client {options} and returns the {HttpResponse}.
+func Call(item postman.Item, env *postman.Env, params []postman.Param, options Options) (*HttpResponse, error) {
+	client, err := NewClient(options)
+	if err != nil {
+		return nil, err
+	}
+	return call(client, item, env, params)
+}
+
+// call executes the API request with the {client}.
+func call(client *http.Client, item postman.Item, env *postman.Env, params []postman.Param) (*HttpResponse, error) {
+	body, contentType, err := buildBody(item.Request.Body, env, params, item.Scope)
 	if err != nil {
 		return nil, err
 	}
 
-	if item.Request.Auth.Type == "basic" {
-		username, password := item.Request.Auth.GetAuthBasicCredential(env, params)
-		r.SetBasicAuth(username, password)
+	r, err := httpsutil.NewHttpRequest(item.Request.Url.Get(env, params, item.Scope), body)
+	if err != nil {
+		return nil, err
 	}
 
-	return r.
+	r.
 		Method(item.Request.Method).
-		Headers(item.Request.Header.Get(env, params)).
-		AsJson().Call()
+		Header("Content-Type", contentType).
+		Headers(item.Request.Header.Get(env, params, item.Scope))
+
+	if item.Request.Body.GetMode() == postman.BodyModeFormData {
+		// the multipart boundary cannot be overridden by the item headers
+		r.Header("Content-Type", contentType)
+	}
+
+	if err := setAuth(client, r, item.Request.Auth, body, env, params, item.Scope); err != nil {
+		return nil, err
+	}
+
+	return do(client, r.Req)
+}
+
+// do sends the HTTP request {req} and reads the full response.
+func do(client *http.Client, req *http.Request) (*HttpResponse, error) {
+	requestHeader := req.Header.Clone()
+
+	start := time.Now()
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	return &HttpResponse{
+		HttpResponse: httpsutil.HttpResponse{
+			Status:        resp.Status,
+			StatusCode:    resp.StatusCode,
+			Body:          body,
+			ContentLength: resp.ContentLength,
+			TimeInMillis:  time.Since(start).Milliseconds(),
+		},
+		Url:           resp.Request.URL.String(),
+		Proto:         resp.Proto,
+		Header:        resp.Header,
+		RequestHeader: requestHeader,
+	}, nil
 }
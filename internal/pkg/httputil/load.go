@@ -0,0 +1,157 @@
+package httputil
+
+import (
+	"math"
+	"net/http"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/joakim-ribier/gcli-4postman/internal/postman"
+)
+
+// LoadReport is the report of a load test (the latencies are in milliseconds).
+type LoadReport struct {
+	Label       string
+	Url         string
+	Requests    int
+	Concurrency int
+	DurationMs  float64
+	Throughput  float64        // requests per second
+	Statuses    map[string]int // number of responses by status (or error)
+	Errors      int            // requests which cannot be called
+	Latency     LoadLatency
+	Histogram   []LoadHistogramBucket
+}
+
+type LoadLatency struct {
+	Min  float64
+	Mean float64
+	P50  float64
+	P90  float64
+	P99  float64
+	Max  float64
+}
+
+// LoadHistogramBucket is the number of requests with a latency lower or equal than {UpperMs}.
+type LoadHistogramBucket struct {
+	UpperMs float64
+	Count   int
+}
+
+// histogramBuckets is the number of buckets of the latency histogram.
+const histogramBuckets = 10
+
+// Load calls {requests} times the {item} API request by {concurrency} workers sharing the same HTTP client
+// (the cookies are not used) and returns the report.
+func Load(item postman.Item, env *postman.Env, params []postman.Param, options Options, requests, concurrency int) (*LoadReport, error) {
+	options.Jar = nil
+	client, err := NewClient(options)
+	if err != nil {
+		return nil, err
+	}
+	concurrency = max(min(concurrency, requests), 1)
+	if transport, ok := client.Transport.(*http.Transport); ok {
+		transport.MaxIdleConnsPerHost = concurrency
+	}
+
+	var mu sync.Mutex
+	latencies := make([]float64, 0, requests)
+	statuses := map[string]int{}
+	errors := 0
+
+	jobs := make(chan struct{}, requests)
+	for i := 0; i < requests; i++ {
+		jobs <- struct{}{}
+	}
+	close(jobs)
+
+	start := time.Now()
+	var wg sync.WaitGroup
+	for w := 0; w < concurrency; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range jobs {
+				begin := time.Now()
+				response, err := call(client, item, env, params)
+				latency := float64(time.Since(begin).Microseconds()) / 1000
+
+				mu.Lock()
+				if err != nil {
+					errors++
+					statuses["error"]++
+				} else {
+					latencies = append(latencies, latency)
+					statuses[response.Status]++
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	elapsed := time.Since(start)
+
+	return &LoadReport{
+		Label:       item.GetLabel(),
+		Url:         item.Request.Url.Get(env, params, item.Scope),
+		Requests:    requests,
+		Concurrency: concurrency,
+		DurationMs:  float64(elapsed.Microseconds()) / 1000,
+		Throughput:  float64(requests) / elapsed.Seconds(),
+		Statuses:    statuses,
+		Errors:      errors,
+		Latency:     newLoadLatency(latencies),
+		Histogram:   newLoadHistogram(latencies),
+	}, nil
+}
+
+// newLoadLatency computes the latency statistics.
+func newLoadLatency(latencies []float64) LoadLatency {
+	if len(latencies) == 0 {
+		return LoadLatency{}
+	}
+	sorted := slices.Clone(latencies)
+	slices.Sort(sorted)
+
+	var sum float64
+	for _, latency := range sorted {
+		sum += latency
+	}
+	return LoadLatency{
+		Min:  sorted[0],
+		Mean: sum / float64(len(sorted)),
+		P50:  percentile(sorted, 50),
+		P90:  percentile(sorted, 90),
+		P99:  percentile(sorted, 99),
+		Max:  sorted[len(sorted)-1],
+	}
+}
+
+// percentile returns the {p} percentile (nearest-rank method) of the {sorted} values.
+func percentile(sorted []float64, p float64) float64 {
+	rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+	return sorted[max(rank, 1)-1]
+}
+
+// newLoadHistogram distributes the latencies in linear buckets between the min and the max.
+func newLoadHistogram(latencies []float64) []LoadHistogramBucket {
+	if len(latencies) == 0 {
+		return nil
+	}
+	lowest, highest := slices.Min(latencies), slices.Max(latencies)
+	width := (highest - lowest) / histogramBuckets
+
+	buckets := make([]LoadHistogramBucket, histogramBuckets)
+	for i := range buckets {
+		buckets[i].UpperMs = lowest + width*float64(i+1)
+	}
+	for _, latency := range latencies {
+		i := histogramBuckets - 1
+		if width > 0 {
+			i = min(int((latency-lowest)/width), histogramBuckets-1)
+		}
+		buckets[i].Count++
+	}
+	return buckets
+}
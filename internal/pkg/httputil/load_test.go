@@ -0,0 +1,97 @@
+package httputil
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/joakim-ribier/gcli-4postman/internal/postman"
+)
+
+func TestLoad(t *testing.T) {
+	const (
+		requests    = 100
+		concurrency = 4
+	)
+
+	var calls, inFlight, maxInFlight atomic.Int64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := calls.Add(1)
+		current := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			peak := maxInFlight.Load()
+			if current <= peak || maxInFlight.CompareAndSwap(peak, current) {
+				break
+			}
+		}
+		time.Sleep(time.Duration(n%5) * time.Millisecond)
+
+		if n%10 == 0 {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	item := postman.Item{Name: "load", Request: postman.Request{Method: "GET", Url: postman.Url{Raw: server.URL + "/load"}}}
+	report, err := Load(item, nil, nil, Options{}, requests, concurrency)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	if got := calls.Load(); got != requests {
+		t.Errorf("server calls = %d, want %d", got, requests)
+	}
+	if report.Requests != requests || report.Concurrency != concurrency {
+		t.Errorf("report requests/concurrency = %d/%d, want %d/%d", report.Requests, report.Concurrency, requests, concurrency)
+	}
+	if got := maxInFlight.Load(); got > concurrency {
+		t.Errorf("max in-flight requests = %d, want <= %d", got, concurrency)
+	}
+
+	if report.Errors != 0 {
+		t.Errorf("errors = %d, want 0", report.Errors)
+	}
+	if got := report.Statuses["200 OK"]; got != 90 {
+		t.Errorf("statuses[200 OK] = %d, want 90", got)
+	}
+	if got := report.Statuses["500 Internal Server Error"]; got != 10 {
+		t.Errorf("statuses[500 Internal Server Error] = %d, want 10", got)
+	}
+
+	latency := report.Latency
+	if !(latency.Min <= latency.P50 && latency.P50 <= latency.P90 && latency.P90 <= latency.P99 && latency.P99 <= latency.Max) {
+		t.Errorf("latencies are not ordered: %+v", latency)
+	}
+
+	count := 0
+	for _, bucket := range report.Histogram {
+		count += bucket.Count
+	}
+	if count != requests {
+		t.Errorf("histogram count = %d, want %d", count, requests)
+	}
+}
+
+func TestLoadWithErrors(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	url := server.URL
+	server.Close()
+
+	item := postman.Item{Name: "load", Request: postman.Request{Method: "GET", Url: postman.Url{Raw: url}}}
+	report, err := Load(item, nil, nil, Options{Timeout: 1}, 10, 2)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	if report.Errors != 10 || report.Statuses["error"] != 10 {
+		t.Errorf("errors = %d (statuses %v), want 10", report.Errors, report.Statuses)
+	}
+	if report.Latency != (LoadLatency{}) {
+		t.Errorf("latency = %+v, want empty", report.Latency)
+	}
+}
@@ -0,0 +1,545 @@
+package openapiutil
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/joakim-ribier/gcli-4postman/internal/postman"
+	"gopkg.in/yaml.v3"
+)
+
+// BaseUrl is the collection variable which contains the API server url.
+const BaseUrl = "baseUrl"
+
+// maxRefs is the max number of references followed to resolve a node.
+const maxRefs = 8
+
+// methods are the operations of a path item (in the collection order).
+var methods = []string{"get", "put", "post", "patch", "delete", "head", "options", "trace"}
+
+// spec is an OpenAPI 3.x or Swagger 2.0 document.
+type spec struct {
+	doc      map[string]any
+	swagger  bool
+	basePath string // relative server url (no host)
+}
+
+// Parse converts the OpenAPI 3.x or Swagger 2.0 (JSON or YAML) {data} document into a collection:
+// a folder by tag, a request by operation and the {{baseUrl}} variable from the servers
+// (not declared if the document has no server host, to be set in the environment).
+func Parse(data []byte) (*postman.Collection, error) {
+	doc, err := decode(data)
+	if err != nil {
+		return nil, err
+	}
+
+	s := spec{doc: doc}
+	switch {
+	case strings.HasPrefix(getString(doc, "openapi"), "3."):
+	case getString(doc, "swagger") == "2.0":
+		s.swagger = true
+	default:
+		return nil, errors.New("the document is not an OpenAPI 3.x or Swagger 2.0 specification")
+	}
+
+	info := getMap(doc, "info")
+	collection := &postman.Collection{
+		Info:  postman.Info{Name: getString(info, "title")},
+		Items: postman.Items{},
+	}
+	if baseUrl := s.getBaseUrl(); strings.Contains(baseUrl, "://") {
+		collection.Variables = postman.Variables{{Key: BaseUrl, Value: baseUrl}}
+	} else {
+		// the relative base path is kept in the requests paths
+		s.basePath = baseUrl
+	}
+	if collection.Info.Name == "" {
+		collection.Info.Name = "openapi"
+	}
+
+	folders := map[string]int{}
+	requests := postman.Items{}
+	paths := getMap(doc, "paths")
+	for _, path := range sortedKeys(paths) {
+		pathItem := s.resolve(getMap(paths, path))
+		for _, method := range methods {
+			operation := getMap(pathItem, method)
+			if operation == nil {
+				continue
+			}
+			item := s.newItem(path, method, operation, getSlice(pathItem, "parameters"))
+
+			tags := getSlice(operation, "tags")
+			if len(tags) == 0 {
+				requests = append(requests, item)
+				continue
+			}
+			tag := fmt.Sprint(tags[0])
+			if _, ok := folders[tag]; !ok {
+				folders[tag] = len(collection.Items)
+				collection.Items = append(collection.Items, postman.Item{Name: tag, Items: postman.Items{}})
+			}
+			collection.Items[folders[tag]].Items = append(collection.Items[folders[tag]].Items, item)
+		}
+	}
+	collection.Items = append(collection.Items, requests...)
+
+	return collection, nil
+}
+
+// decode decodes the JSON or YAML {data} document.
+func decode(data []byte) (map[string]any, error) {
+	var doc any
+	if json.Valid(data) {
+		if err := json.Unmarshal(data, &doc); err != nil {
+			return nil, err
+		}
+	} else if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	if out, ok := normalize(doc).(map[string]any); ok {
+		return out, nil
+	}
+	return nil, errors.New("the document is not a JSON or YAML object")
+}
+
+// normalize converts the YAML mappings (the keys can be numbers like the responses codes) into JSON objects.
+func normalize(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		for key, el := range v {
+			v[key] = normalize(el)
+		}
+		return v
+	case map[any]any:
+		out := make(map[string]any, len(v))
+		for key, el := range v {
+			out[fmt.Sprint(key)] = normalize(el)
+		}
+		return out
+	case []any:
+		for i, el := range v {
+			v[i] = normalize(el)
+		}
+		return v
+	default:
+		return v
+	}
+}
+
+// getBaseUrl returns the url of the first server ("servers" for OpenAPI 3, "schemes", "host" and "basePath" for Swagger 2).
+func (s spec) getBaseUrl() string {
+	if s.swagger {
+		host := getString(s.doc, "host")
+		basePath := strings.TrimSuffix(getString(s.doc, "basePath"), "/")
+		if host == "" {
+			return basePath
+		}
+		scheme := "https"
+		if schemes := getSlice(s.doc, "schemes"); len(schemes) > 0 {
+			scheme = fmt.Sprint(schemes[0])
+		}
+		return scheme + "://" + host + basePath
+	}
+
+	servers := getSlice(s.doc, "servers")
+	if len(servers) == 0 {
+		return ""
+	}
+	server, _ := servers[0].(map[string]any)
+	out := getString(server, "url")
+	variables := getMap(server, "variables")
+	for key := range variables {
+		out = strings.ReplaceAll(out, "{"+key+"}", getString(getMap(variables, key), "default"))
+	}
+	return strings.TrimSuffix(out, "/")
+}
+
+// newItem builds the request of the {method} {path} {operation} (the {common} parameters are the path item ones).
+func (s spec) newItem(path, method string, operation map[string]any, common []any) postman.Item {
+	name := getString(operation, "summary")
+	if name == "" {
+		name = getString(operation, "operationId")
+	}
+	if name == "" {
+		name = strings.ToUpper(method) + " " + path
+	}
+
+	request := postman.Request{Method: strings.ToUpper(method), Header: postman.Headers{}}
+
+	segments := []string{}
+	for _, segment := range strings.Split(strings.Trim(s.basePath+"/"+path, "/"), "/") {
+		if segment == "" {
+			continue
+		}
+		if strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}") {
+			segment = ":" + strings.TrimSuffix(strings.TrimPrefix(segment, "{"), "}")
+		}
+		segments = append(segments, segment)
+	}
+	request.Url = postman.Url{Host: []string{"{{" + BaseUrl + "}}"}, Path: segments}
+
+	var formData []map[string]any
+	for _, parameter := range s.getParameters(operation, common) {
+		value := s.getParameterValue(parameter)
+		required, _ := parameter["required"].(bool)
+		switch getString(parameter, "in") {
+		case "path":
+			request.Url.Variable = append(request.Url.Variable, postman.UrlParam{Key: getString(parameter, "name"), Value: value})
+		case "query":
+			request.Url.Query = append(request.Url.Query, postman.UrlParam{Key: getString(parameter, "name"), Value: value, Disabled: !required})
+		case "header":
+			request.Header = append(request.Header, postman.Header{Key: getString(parameter, "name"), Value: value, Disabled: !required})
+		case "body":
+			// Swagger 2 body
+			request.Body, request.Header = s.newBody(s.getConsumes(operation), nil, getMap(parameter, "schema"), request.Header)
+		case "formData":
+			formData = append(formData, parameter)
+		}
+	}
+	if len(formData) > 0 {
+		request.Body = s.newFormDataBody(s.getConsumes(operation), formData)
+	}
+
+	if body := s.resolve(getMap(operation, "requestBody")); body != nil {
+		// OpenAPI 3 body
+		content := getMap(body, "content")
+		if mediaType := chooseMediaType(sortedKeys(content)); mediaType != "" {
+			media := getMap(content, mediaType)
+			request.Body, request.Header = s.newBody(mediaType, media, getMap(media, "schema"), request.Header)
+		}
+	}
+
+	request.Url.Raw = "{{" + BaseUrl + "}}/" + strings.Join(segments, "/")
+	if len(request.Url.Query) > 0 {
+		var query []string
+		for _, param := range request.Url.Query.Enabled() {
+			query = append(query, param.Key+"="+param.Value)
+		}
+		if len(query) > 0 {
+			request.Url.Raw += "?" + strings.Join(query, "&")
+		}
+	}
+
+	return postman.Item{Name: name, Request: request}
+}
+
+// getParameters returns the {operation} parameters merged with the {common} ones (overridden by "in" and "name").
+func (s spec) getParameters(operation map[string]any, common []any) []map[string]any {
+	var out []map[string]any
+	for _, values := range [][]any{common, getSlice(operation, "parameters")} {
+		for _, value := range values {
+			parameter, _ := value.(map[string]any)
+			if parameter = s.resolve(parameter); parameter == nil {
+				continue
+			}
+			out = slices.DeleteFunc(out, func(p map[string]any) bool {
+				return getString(p, "in") == getString(parameter, "in") && getString(p, "name") == getString(parameter, "name")
+			})
+			out = append(out, parameter)
+		}
+	}
+	return out
+}
+
+// getParameterValue returns the example value of the {parameter} (example, default or first enum value).
+func (s spec) getParameterValue(parameter map[string]any) string {
+	if v, ok := parameter["example"]; ok {
+		return toString(v)
+	}
+	if examples := getMap(parameter, "examples"); len(examples) > 0 {
+		return toString(s.resolve(getMap(examples, sortedKeys(examples)[0]))["value"])
+	}
+	schema := parameter
+	if !s.swagger {
+		schema = s.resolve(getMap(parameter, "schema"))
+	}
+	for _, key := range []string{"example", "default"} {
+		if v, ok := schema[key]; ok {
+			return toString(v)
+		}
+	}
+	if enum := getSlice(schema, "enum"); len(enum) > 0 {
+		return toString(enum[0])
+	}
+	return ""
+}
+
+// getConsumes returns the Swagger 2 media type of the {operation} body (JSON by default).
+func (s spec) getConsumes(operation map[string]any) string {
+	consumes := getSlice(operation, "consumes")
+	if len(consumes) == 0 {
+		consumes = getSlice(s.doc, "consumes")
+	}
+	mediaTypes := []string{}
+	for _, consume := range consumes {
+		mediaTypes = append(mediaTypes, fmt.Sprint(consume))
+	}
+	if mediaType := chooseMediaType(mediaTypes); mediaType != "" {
+		return mediaType
+	}
+	return "application/json"
+}
+
+// newBody builds the {mediaType} body from the {media} examples (OpenAPI 3) or from the {schema}.
+func (s spec) newBody(mediaType string, media map[string]any, schema map[string]any, headers postman.Headers) (postman.Body, postman.Headers) {
+	if strings.Contains(mediaType, "form-urlencoded") || strings.Contains(mediaType, "multipart/") {
+		body := postman.Body{Mode: postman.BodyModeUrlEncoded}
+		if strings.Contains(mediaType, "multipart/") {
+			body.Mode = postman.BodyModeFormData
+		}
+		properties := getMap(s.merge(schema, nil), "properties")
+		for _, key := range sortedKeys(properties) {
+			field := postman.BodyParam{Key: key, Value: toString(s.example(getMap(properties, key), nil))}
+			if body.Mode == postman.BodyModeFormData && getString(getMap(properties, key), "format") == "binary" {
+				field.Type, field.Value = "file", ""
+			}
+			if body.Mode == postman.BodyModeFormData {
+				body.FormData = append(body.FormData, field)
+			} else {
+				body.UrlEncoded = append(body.UrlEncoded, field)
+			}
+		}
+		return body, headers
+	}
+
+	var example any
+	if v, ok := media["example"]; ok {
+		example = v
+	} else if examples := getMap(media, "examples"); len(examples) > 0 {
+		example = s.resolve(getMap(examples, sortedKeys(examples)[0]))["value"]
+	} else if schema != nil {
+		example = s.example(schema, nil)
+	}
+
+	body := postman.Body{Mode: postman.BodyModeRaw}
+	language := "text"
+	if strings.Contains(mediaType, "json") {
+		language = "json"
+		if data, err := json.MarshalIndent(example, "", "  "); err == nil && example != nil {
+			body.Raw = string(data)
+		}
+	} else if example != nil {
+		if strings.Contains(mediaType, "xml") {
+			language = "xml"
+		}
+		body.Raw = toString(example)
+	}
+	body.Options = &postman.BodyOptions{}
+	body.Options.Raw.Language = language
+
+	if !slices.ContainsFunc(headers, func(h postman.Header) bool { return strings.EqualFold(h.Key, "Content-Type") }) {
+		headers = append(headers, postman.Header{Key: "Content-Type", Value: mediaType})
+	}
+	return body, headers
+}
+
+// newFormDataBody builds the Swagger 2 "formData" {parameters} body.
+func (s spec) newFormDataBody(mediaType string, parameters []map[string]any) postman.Body {
+	body := postman.Body{Mode: postman.BodyModeUrlEncoded}
+	if strings.Contains(mediaType, "multipart/") || slices.ContainsFunc(parameters, func(p map[string]any) bool { return getString(p, "type") == "file" }) {
+		body.Mode = postman.BodyModeFormData
+	}
+	for _, parameter := range parameters {
+		field := postman.BodyParam{Key: getString(parameter, "name"), Value: s.getParameterValue(parameter)}
+		if getString(parameter, "type") == "file" {
+			field.Type = "file"
+		}
+		if body.Mode == postman.BodyModeFormData {
+			body.FormData = append(body.FormData, field)
+		} else {
+			body.UrlEncoded = append(body.UrlEncoded, field)
+		}
+	}
+	return body
+}
+
+// example builds an example value of the {schema} (example, default, enum or built from the type),
+// the {refs} are the references already followed to stop the recursive schemas.
+func (s spec) example(schema map[string]any, refs []string) any {
+	merged := s.merge(schema, refs)
+	if ref := getString(schema, "$ref"); ref != "" {
+		refs = append(slices.Clone(refs), ref)
+	}
+	schema = merged
+	if schema == nil {
+		return nil
+	}
+	for _, key := range []string{"example", "default"} {
+		if v, ok := schema[key]; ok {
+			return v
+		}
+	}
+	if enum := getSlice(schema, "enum"); len(enum) > 0 {
+		return enum[0]
+	}
+	for _, key := range []string{"oneOf", "anyOf"} {
+		if schemas := getSlice(schema, key); len(schemas) > 0 {
+			first, _ := schemas[0].(map[string]any)
+			return s.example(first, refs)
+		}
+	}
+
+	switch getType(schema) {
+	case "object":
+		out := map[string]any{}
+		properties := getMap(schema, "properties")
+		for key := range properties {
+			if v := s.example(getMap(properties, key), refs); v != nil {
+				out[key] = v
+			}
+		}
+		return out
+	case "array":
+		if item := s.example(getMap(schema, "items"), refs); item != nil {
+			return []any{item}
+		}
+		return []any{}
+	case "integer", "number":
+		return 0
+	case "boolean":
+		return true
+	case "string":
+		switch getString(schema, "format") {
+		case "date":
+			return "1970-01-01"
+		case "date-time":
+			return "1970-01-01T00:00:00Z"
+		case "uuid":
+			return "00000000-0000-0000-0000-000000000000"
+		case "email":
+			return "user@example.com"
+		}
+		return "string"
+	}
+	return nil
+}
+
+// merge resolves the {schema} and merges its "allOf" schemas,
+// the {refs} are the references already followed to stop the recursive schemas.
+func (s spec) merge(schema map[string]any, refs []string) map[string]any {
+	if ref := getString(schema, "$ref"); ref != "" {
+		if slices.Contains(refs, ref) {
+			return nil
+		}
+		refs = append(slices.Clone(refs), ref)
+	}
+	schema = s.resolve(schema)
+	allOf := getSlice(schema, "allOf")
+	if len(allOf) == 0 {
+		return schema
+	}
+	out := map[string]any{"type": "object"}
+	properties := map[string]any{}
+	for _, value := range append(allOf, map[string]any{"properties": schema["properties"]}) {
+		sub, _ := value.(map[string]any)
+		for key, property := range getMap(s.merge(sub, refs), "properties") {
+			properties[key] = property
+		}
+	}
+	for key, value := range schema {
+		if key != "allOf" && key != "properties" {
+			out[key] = value
+		}
+	}
+	out["properties"] = properties
+	return out
+}
+
+// resolve follows the local "$ref" ("#/components/schemas/User") of the {node}.
+func (s spec) resolve(node map[string]any) map[string]any {
+	for i := 0; node != nil && i < maxRefs; i++ {
+		ref := getString(node, "$ref")
+		if ref == "" {
+			return node
+		}
+		if !strings.HasPrefix(ref, "#/") {
+			// external references are not supported
+			return nil
+		}
+		var current any = s.doc
+		for _, key := range strings.Split(strings.TrimPrefix(ref, "#/"), "/") {
+			key = strings.ReplaceAll(strings.ReplaceAll(key, "~1", "/"), "~0", "~")
+			m, _ := current.(map[string]any)
+			current = m[key]
+		}
+		node, _ = current.(map[string]any)
+	}
+	return node
+}
+
+// chooseMediaType returns the JSON media type if it exists, the first one otherwise.
+func chooseMediaType(mediaTypes []string) string {
+	if i := slices.IndexFunc(mediaTypes, func(m string) bool { return strings.Contains(m, "json") }); i > -1 {
+		return mediaTypes[i]
+	}
+	if len(mediaTypes) > 0 {
+		return mediaTypes[0]
+	}
+	return ""
+}
+
+// getType returns the {schema} type (deduced from the properties or the items if not provided).
+func getType(schema map[string]any) string {
+	switch v := schema["type"].(type) {
+	case string:
+		return v
+	case []any:
+		// OpenAPI 3.1 types ["string", "null"]
+		for _, t := range v {
+			if t != "null" {
+				return fmt.Sprint(t)
+			}
+		}
+	}
+	if _, ok := schema["properties"]; ok {
+		return "object"
+	}
+	if _, ok := schema["items"]; ok {
+		return "array"
+	}
+	return ""
+}
+
+func getMap(m map[string]any, key string) map[string]any {
+	v, _ := m[key].(map[string]any)
+	return v
+}
+
+func getSlice(m map[string]any, key string) []any {
+	v, _ := m[key].([]any)
+	return v
+}
+
+func getString(m map[string]any, key string) string {
+	v, _ := m[key].(string)
+	return v
+}
+
+// toString returns the {value} as a string (JSON encoded if it is not a scalar).
+func toString(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case map[string]any, []any:
+		data, _ := json.Marshal(v)
+		return string(data)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+func sortedKeys(m map[string]any) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -2,16 +2,32 @@ package prettyprint
 
 import (
 	"fmt"
+	"io"
+	"os"
 	"strings"
 
 	"github.com/jedib0t/go-pretty/v6/text"
 	"github.com/tidwall/pretty"
 )
 
+// colors is false when the colors are disabled (stdout is not a terminal or structured output).
+var colors = true
+
+// DisableColors disables the colors of the texts and of the json values.
+func DisableColors() {
+	colors = false
+	text.DisableColors()
+}
+
 // Print prints text on the console.
 func Print(value string) {
+	Fprint(os.Stdout, value)
+}
+
+// Fprint prints text on the {w} writer.
+func Fprint(w io.Writer, value string) {
 	for _, line := range strings.Split(value, "\n") {
-		fmt.Printf("%s\n", line)
+		fmt.Fprintf(w, "%s\n", line)
 	}
 }
 
@@ -20,6 +36,9 @@ func SPrintJson(data []byte, prettyJson bool) string {
 	if prettyJson {
 		data = pretty.Pretty(data)
 	}
+	if !colors {
+		return string(data)
+	}
 	return string(pretty.Color(data, nil)[:])
 }
 
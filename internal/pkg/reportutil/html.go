@@ -0,0 +1,74 @@
+package reportutil
+
+import (
+	"bytes"
+	_ "embed"
+	"html/template"
+	"time"
+
+	"github.com/joakim-ribier/gcli-4postman/internal/postman"
+)
+
+//go:embed report.html
+var htmlTemplate string
+
+type htmlReport struct {
+	Name        string
+	GeneratedAt string
+	Requests    int
+	Failed      int
+	Tests       int
+	TestsPassed int
+	TimeInMs    int64
+	Results     []htmlResult
+}
+
+type htmlResult struct {
+	Number       int
+	Name         string
+	Method       string
+	Url          string
+	Status       string
+	TimeInMillis int64
+	Error        string
+	Failed       bool
+	Tests        postman.TestResults
+}
+
+// HTML builds the self-contained (no external resource) HTML report of the {results}.
+func HTML(name string, results postman.CollectionRunResults) ([]byte, error) {
+	t, err := template.New("report").Parse(htmlTemplate)
+	if err != nil {
+		return nil, err
+	}
+
+	iterations := len(results.GetIterations())
+	report := htmlReport{Name: name, GeneratedAt: time.Now().Format("2006-01-02 15:04:05"), Requests: len(results)}
+	for i, result := range results {
+		r := htmlResult{Number: i + 1, Name: getName(result, iterations), Error: result.Error, Failed: result.HasFailed()}
+		if result.Response != nil {
+			r.Method = result.Response.Item.Request.Method
+			r.Url = getUrl(*result.Response)
+			r.Status = result.Response.Status
+			r.TimeInMillis = result.Response.TimeInMillis
+			r.Tests = result.Response.TestResults
+			report.TimeInMs += result.Response.TimeInMillis
+		}
+		if r.Failed {
+			report.Failed++
+		}
+		for _, test := range r.Tests {
+			report.Tests++
+			if test.Passed {
+				report.TestsPassed++
+			}
+		}
+		report.Results = append(report.Results, r)
+	}
+
+	var buffer bytes.Buffer
+	if err := t.Execute(&buffer, report); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
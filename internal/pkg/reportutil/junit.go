@@ -0,0 +1,125 @@
+package reportutil
+
+import (
+	"encoding/xml"
+	"fmt"
+	"time"
+
+	"github.com/joakim-ribier/gcli-4postman/internal/postman"
+)
+
+type junitTestSuites struct {
+	XMLName    xml.Name         `xml:"testsuites"`
+	Name       string           `xml:"name,attr"`
+	Tests      int              `xml:"tests,attr"`
+	Failures   int              `xml:"failures,attr"`
+	Errors     int              `xml:"errors,attr"`
+	Time       string           `xml:"time,attr"`
+	TestSuites []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name       string          `xml:"name,attr"`
+	Tests      int             `xml:"tests,attr"`
+	Failures   int             `xml:"failures,attr"`
+	Errors     int             `xml:"errors,attr"`
+	Time       string          `xml:"time,attr"`
+	Timestamp  string          `xml:"timestamp,attr,omitempty"`
+	Properties []junitProperty `xml:"properties>property,omitempty"`
+	TestCases  []junitTestCase `xml:"testcase"`
+}
+
+type junitProperty struct {
+	Name  string `xml:"name,attr"`
+	Value string `xml:"value,attr"`
+}
+
+type junitTestCase struct {
+	ClassName string        `xml:"classname,attr"`
+	Name      string        `xml:"name,attr"`
+	Time      string        `xml:"time,attr"`
+	Failure   *junitProblem `xml:"failure,omitempty"`
+	Error     *junitProblem `xml:"error,omitempty"`
+}
+
+type junitProblem struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+}
+
+// JUnit builds the JUnit XML report of the {results}: a test suite by executed request
+// and a test case by test (or assertion) result, a request which cannot be called is a test case in error.
+func JUnit(name string, results postman.CollectionRunResults) ([]byte, error) {
+	iterations := len(results.GetIterations())
+	root := junitTestSuites{Name: name}
+
+	var total float64
+	for _, result := range results {
+		suite := junitTestSuite{
+			Name: getName(result, iterations),
+			Time: formatSeconds(getTimeInSeconds(result)),
+			Properties: []junitProperty{
+				{Name: "iteration", Value: fmt.Sprint(result.Iteration)},
+			},
+		}
+		total += getTimeInSeconds(result)
+
+		switch {
+		case result.Response == nil:
+			suite.TestCases = append(suite.TestCases, junitTestCase{
+				ClassName: result.Label,
+				Name:      result.Label,
+				Time:      formatSeconds(0),
+				Error:     &junitProblem{Message: result.Error, Type: "RequestError"},
+			})
+		case len(result.Response.TestResults) == 0:
+			// no test, the request is successful if it has been called
+			suite.TestCases = append(suite.TestCases, junitTestCase{
+				ClassName: result.Label,
+				Name:      fmt.Sprintf("STATUS=%s", result.Response.Status),
+				Time:      suite.Time,
+			})
+		default:
+			for _, test := range result.Response.TestResults {
+				testCase := junitTestCase{ClassName: result.Label, Name: test.Name, Time: formatSeconds(0)}
+				if !test.Passed {
+					testCase.Failure = &junitProblem{Message: test.Error, Type: "AssertionFailure"}
+				}
+				suite.TestCases = append(suite.TestCases, testCase)
+			}
+		}
+
+		if result.Response != nil {
+			suite.Timestamp = result.Response.ExecutedAt.Format(time.RFC3339)
+			suite.Properties = append(suite.Properties,
+				junitProperty{Name: "method", Value: result.Response.Item.Request.Method},
+				junitProperty{Name: "url", Value: getUrl(*result.Response)},
+				junitProperty{Name: "status", Value: result.Response.Status})
+		}
+
+		for _, testCase := range suite.TestCases {
+			suite.Tests++
+			if testCase.Failure != nil {
+				suite.Failures++
+			}
+			if testCase.Error != nil {
+				suite.Errors++
+			}
+		}
+		root.Tests += suite.Tests
+		root.Failures += suite.Failures
+		root.Errors += suite.Errors
+		root.TestSuites = append(root.TestSuites, suite)
+	}
+	root.Time = formatSeconds(total)
+
+	data, err := xml.MarshalIndent(root, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), append(data, '\n')...), nil
+}
+
+func formatSeconds(seconds float64) string {
+	return fmt.Sprintf("%.3f", seconds)
+}
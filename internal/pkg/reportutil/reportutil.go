@@ -0,0 +1,79 @@
+package reportutil
+
+import (
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/joakim-ribier/gcli-4postman/internal/postman"
+	"github.com/joakim-ribier/go-utils/pkg/stringsutil"
+)
+
+// maskedValue replaces the auth values (api key, OAuth2 token) of the query params in the reports.
+const maskedValue = "********"
+
+// Report is a report file: its {Format} ("junit" or "html") and its {Path}.
+type Report struct {
+	Format string
+	Path   string
+}
+
+// ParseReport parses the "{format}:{path}" report {value}.
+func ParseReport(value string) (Report, error) {
+	format, path, found := strings.Cut(value, ":")
+	if !found || path == "" {
+		return Report{}, fmt.Errorf("report {%s} must be defined as {format}:{path}", value)
+	}
+	format = strings.ToLower(format)
+	if format != "junit" && format != "html" {
+		return Report{}, fmt.Errorf("report format {%s} does not exist (junit or html)", format)
+	}
+	return Report{Format: format, Path: path}, nil
+}
+
+// Build builds the report of the execution {results} of the {name} collection.
+func (r Report) Build(name string, results postman.CollectionRunResults) ([]byte, error) {
+	if r.Format == "html" {
+		return HTML(name, results)
+	}
+	return JUnit(name, results)
+}
+
+// getTimeInSeconds returns the request time of the {result} in seconds.
+func getTimeInSeconds(result postman.CollectionRunResult) float64 {
+	if result.Response == nil {
+		return 0
+	}
+	return float64(result.Response.TimeInMillis) / 1000
+}
+
+// getName returns the {result} name, suffixed by its iteration if the run has several ones.
+func getName(result postman.CollectionRunResult, iterations int) string {
+	if iterations > 1 {
+		return fmt.Sprintf("%s [%d/%d]", result.Label, result.Iteration, iterations)
+	}
+	return result.Label
+}
+
+// getUrl returns the URL called by the {response}, the values of the query params added by the auth are masked.
+func getUrl(response postman.CollectionHistoryItem) string {
+	raw := stringsutil.OrElse(response.Url, response.Item.Request.Url.Get(response.Env, response.Params, response.Scope))
+	keys := response.Item.Request.Auth.GetQueryParams(response.Env, response.Params, response.Scope)
+	if len(keys) == 0 {
+		return raw
+	}
+	u, err := url.Parse(raw)
+	if err != nil {
+		return raw
+	}
+	pairs := strings.Split(u.RawQuery, "&")
+	for i, pair := range pairs {
+		key, _, _ := strings.Cut(pair, "=")
+		if k, err := url.QueryUnescape(key); err == nil && slices.Contains(keys, k) {
+			pairs[i] = key + "=" + maskedValue
+		}
+	}
+	u.RawQuery = strings.Join(pairs, "&")
+	return u.String()
+}
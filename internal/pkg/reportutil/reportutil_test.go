@@ -0,0 +1,41 @@
+package reportutil
+
+import (
+	"testing"
+
+	"github.com/joakim-ribier/gcli-4postman/internal/postman"
+)
+
+func TestGetUrl(t *testing.T) {
+	scope := postman.Variables{{Key: "apiKey", Value: "s3cr3t"}}
+	newResponse := func(url string, auth postman.Auth) postman.CollectionHistoryItem {
+		return postman.CollectionHistoryItem{
+			Item:  postman.Item{Request: postman.Request{Method: "GET", Url: postman.Url{Raw: "http://h/a"}, Auth: auth}},
+			Url:   url,
+			Scope: scope,
+		}
+	}
+	apiKey := postman.Auth{Type: postman.AuthApiKey, ApiKey: postman.AuthValues{
+		{Key: "key", Value: "api_key"}, {Key: "value", Value: "{{apiKey}}"}, {Key: "in", Value: "query"}}}
+	oauth2 := postman.Auth{Type: postman.AuthOAuth2, OAuth2: postman.AuthValues{
+		{Key: "accessToken", Value: "t0k3n"}, {Key: "addTokenTo", Value: "queryParams"}}}
+	bearer := postman.Auth{Type: postman.AuthBearer, Bearer: postman.AuthValues{{Key: "token", Value: "t0k3n"}}}
+
+	tests := []struct {
+		name     string
+		response postman.CollectionHistoryItem
+		want     string
+	}{
+		{"api key in query", newResponse("http://h/a?api_key=s3cr3t&x=1", apiKey), "http://h/a?api_key=********&x=1"},
+		{"oauth2 token in query", newResponse("http://h/a?access_token=t0k3n", oauth2), "http://h/a?access_token=********"},
+		{"bearer token", newResponse("http://h/a?x=1", bearer), "http://h/a?x=1"},
+		{"url not called", newResponse("", postman.Auth{}), "http://h/a"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := getUrl(test.response); got != test.want {
+				t.Errorf("getUrl() = %q, want %q", got, test.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,268 @@
+// Postman "pm" API (subset) built on top of the "__ctx" native bindings.
+var pm = (function (ctx) {
+    function toStr(value) {
+        return value === undefined || value === null ? "" : typeof value === "object" ? JSON.stringify(value) : String(value);
+    }
+
+    function variableScope(scope) {
+        return {
+            get: function (key) { return scope.has(key) ? scope.get(key) : undefined; },
+            set: function (key, value) { scope.set(key, toStr(value)); },
+            unset: function (key) { scope.unset(key); },
+            has: function (key) { return scope.has(key); },
+            toObject: function () { return scope.toObject(); },
+        };
+    }
+
+    // readOnly makes the properties of the {object} read-only, only the request headers can be updated by the scripts.
+    function readOnly(object, name) {
+        Object.keys(object).forEach(function (key) {
+            var value = object[key];
+            Object.defineProperty(object, key, {
+                enumerable: true,
+                get: function () { return value; },
+                set: function () { throw new TypeError(name + "." + key + " is read-only"); },
+            });
+        });
+        return Object.freeze(object);
+    }
+
+    function headerList(headers, mutable) {
+        function find(key) {
+            var list = headers.all();
+            for (var i = 0; i < list.length; i++) {
+                if (list[i].key.toLowerCase() === String(key).toLowerCase()) {
+                    return list[i];
+                }
+            }
+            return undefined;
+        }
+        function parse(header) {
+            if (typeof header === "string") {
+                var i = header.indexOf(":");
+                return { key: header.substring(0, i).trim(), value: header.substring(i + 1).trim() };
+            }
+            return { key: String(header.key), value: toStr(header.value) };
+        }
+        var list = {
+            get: function (key) { var h = find(key); return h ? h.value : undefined; },
+            has: function (key) { return find(key) !== undefined; },
+            all: function () { return headers.all(); },
+            toObject: function () {
+                var out = {};
+                headers.all().forEach(function (h) { out[h.key] = h.value; });
+                return out;
+            },
+        };
+        if (mutable) {
+            list.add = function (header) { var h = parse(header); headers.add(h.key, h.value); };
+            list.upsert = function (header) { var h = parse(header); headers.upsert(h.key, h.value); };
+            list.remove = function (key) { headers.remove(typeof key === "string" ? key : key.key); };
+        }
+        return list;
+    }
+
+    function AssertionError(message) {
+        this.name = "AssertionError";
+        this.message = message;
+    }
+    AssertionError.prototype = Object.create(Error.prototype);
+
+    function typeOf(value) {
+        if (value === null) return "null";
+        if (Array.isArray(value)) return "array";
+        return typeof value;
+    }
+
+    function deepEqual(a, b) {
+        if (a === b) return true;
+        if (typeOf(a) !== typeOf(b) || typeof a !== "object" || a === null) return false;
+        var ka = Object.keys(a), kb = Object.keys(b);
+        if (ka.length !== kb.length) return false;
+        for (var i = 0; i < ka.length; i++) {
+            if (!deepEqual(a[ka[i]], b[ka[i]])) return false;
+        }
+        return true;
+    }
+
+    function inspect(value) {
+        try {
+            return typeof value === "string" ? "'" + value + "'" : JSON.stringify(value);
+        } catch (e) {
+            return String(value);
+        }
+    }
+
+    // Assertion is a chai "expect" like assertion (subset).
+    function Assertion(actual) {
+        this.actual = actual;
+        this.negate = false;
+        this.isDeep = false;
+    }
+
+    Assertion.prototype.assert = function (expr, message, negatedMessage) {
+        if (this.negate ? expr : !expr) {
+            throw new AssertionError(this.negate ? negatedMessage : message);
+        }
+        return this;
+    };
+
+    ["to", "be", "been", "is", "that", "which", "and", "has", "have", "with", "at", "of", "same", "does", "still"].forEach(function (word) {
+        Object.defineProperty(Assertion.prototype, word, { get: function () { return this; } });
+    });
+
+    Object.defineProperty(Assertion.prototype, "not", { get: function () { this.negate = !this.negate; return this; } });
+    Object.defineProperty(Assertion.prototype, "deep", { get: function () { this.isDeep = true; return this; } });
+
+    function getter(name, fn) {
+        Object.defineProperty(Assertion.prototype, name, { get: fn });
+    }
+
+    getter("ok", function () { return this.assert(!!this.actual, "expected " + inspect(this.actual) + " to be truthy", "expected " + inspect(this.actual) + " to be falsy"); });
+    getter("true", function () { return this.assert(this.actual === true, "expected " + inspect(this.actual) + " to be true", "expected " + inspect(this.actual) + " to not be true"); });
+    getter("false", function () { return this.assert(this.actual === false, "expected " + inspect(this.actual) + " to be false", "expected " + inspect(this.actual) + " to not be false"); });
+    getter("null", function () { return this.assert(this.actual === null, "expected " + inspect(this.actual) + " to be null", "expected " + inspect(this.actual) + " to not be null"); });
+    getter("undefined", function () { return this.assert(this.actual === undefined, "expected " + inspect(this.actual) + " to be undefined", "expected " + inspect(this.actual) + " to not be undefined"); });
+    getter("exist", function () { return this.assert(this.actual !== null && this.actual !== undefined, "expected " + inspect(this.actual) + " to exist", "expected " + inspect(this.actual) + " to not exist"); });
+    getter("empty", function () {
+        var a = this.actual;
+        var length = typeOf(a) === "object" ? Object.keys(a).length : a.length;
+        return this.assert(length === 0, "expected " + inspect(a) + " to be empty", "expected " + inspect(a) + " to not be empty");
+    });
+
+    Assertion.prototype.a = Assertion.prototype.an = function (type) {
+        var actualType = typeOf(this.actual);
+        return this.assert(actualType === String(type).toLowerCase(), "expected " + inspect(this.actual) + " to be a " + type, "expected " + inspect(this.actual) + " to not be a " + type);
+    };
+
+    Assertion.prototype.equal = Assertion.prototype.equals = Assertion.prototype.eq = function (expected) {
+        var equal = this.isDeep ? deepEqual(this.actual, expected) : this.actual === expected;
+        return this.assert(equal, "expected " + inspect(this.actual) + " to equal " + inspect(expected), "expected " + inspect(this.actual) + " to not equal " + inspect(expected));
+    };
+
+    Assertion.prototype.eql = function (expected) {
+        return this.assert(deepEqual(this.actual, expected), "expected " + inspect(this.actual) + " to deeply equal " + inspect(expected), "expected " + inspect(this.actual) + " to not deeply equal " + inspect(expected));
+    };
+
+    Assertion.prototype.include = Assertion.prototype.includes = Assertion.prototype.contain = Assertion.prototype.contains = function (expected) {
+        var a = this.actual, found = false;
+        if (typeof a === "string") {
+            found = a.indexOf(expected) > -1;
+        } else if (Array.isArray(a)) {
+            for (var i = 0; i < a.length && !found; i++) found = this.isDeep ? deepEqual(a[i], expected) : a[i] === expected;
+        } else if (a && typeof a === "object") {
+            found = true;
+            for (var key in expected) found = found && deepEqual(a[key], expected[key]);
+        }
+        return this.assert(found, "expected " + inspect(a) + " to include " + inspect(expected), "expected " + inspect(a) + " to not include " + inspect(expected));
+    };
+
+    Assertion.prototype.property = function (name, value) {
+        var a = this.actual;
+        var has = a !== null && a !== undefined && Object.prototype.hasOwnProperty.call(Object(a), name);
+        if (arguments.length > 1) {
+            return this.assert(has && deepEqual(a[name], value), "expected " + inspect(a) + " to have property '" + name + "' of " + inspect(value), "expected " + inspect(a) + " to not have property '" + name + "' of " + inspect(value));
+        }
+        this.assert(has, "expected " + inspect(a) + " to have property '" + name + "'", "expected " + inspect(a) + " to not have property '" + name + "'");
+        if (!this.negate) {
+            this.actual = a[name];
+        }
+        return this;
+    };
+
+    Assertion.prototype.lengthOf = function (length) {
+        var actualLength = this.actual ? this.actual.length : undefined;
+        return this.assert(actualLength === length, "expected " + inspect(this.actual) + " to have a length of " + length + " but got " + actualLength, "expected " + inspect(this.actual) + " to not have a length of " + length);
+    };
+
+    Assertion.prototype.above = Assertion.prototype.gt = Assertion.prototype.greaterThan = function (n) {
+        return this.assert(this.actual > n, "expected " + inspect(this.actual) + " to be above " + n, "expected " + inspect(this.actual) + " to be at most " + n);
+    };
+    Assertion.prototype.below = Assertion.prototype.lt = Assertion.prototype.lessThan = function (n) {
+        return this.assert(this.actual < n, "expected " + inspect(this.actual) + " to be below " + n, "expected " + inspect(this.actual) + " to be at least " + n);
+    };
+    Assertion.prototype.least = Assertion.prototype.gte = function (n) {
+        return this.assert(this.actual >= n, "expected " + inspect(this.actual) + " to be at least " + n, "expected " + inspect(this.actual) + " to be below " + n);
+    };
+    Assertion.prototype.most = Assertion.prototype.lte = function (n) {
+        return this.assert(this.actual <= n, "expected " + inspect(this.actual) + " to be at most " + n, "expected " + inspect(this.actual) + " to be above " + n);
+    };
+    Assertion.prototype.oneOf = function (list) {
+        return this.assert(list.indexOf(this.actual) > -1, "expected " + inspect(this.actual) + " to be one of " + inspect(list), "expected " + inspect(this.actual) + " to not be one of " + inspect(list));
+    };
+    Assertion.prototype.match = function (re) {
+        return this.assert(re.test(this.actual), "expected " + inspect(this.actual) + " to match " + re, "expected " + inspect(this.actual) + " not to match " + re);
+    };
+
+    // response assertions (pm.response.to.have.status(200)...)
+    Assertion.prototype.status = function (expected) {
+        var r = this.actual;
+        var equal = typeof expected === "number" ? r.code === expected : r.status === expected;
+        return this.assert(equal, "expected response to have status " + inspect(expected) + " but got " + r.code, "expected response to not have status " + inspect(expected));
+    };
+    Assertion.prototype.header = function (key, value) {
+        var r = this.actual;
+        if (arguments.length > 1) {
+            return this.assert(r.headers.get(key) === value, "expected response to have header " + key + " with value " + inspect(value), "expected response to not have header " + key + " with value " + inspect(value));
+        }
+        return this.assert(r.headers.has(key), "expected response to have header " + key, "expected response to not have header " + key);
+    };
+    Assertion.prototype.jsonBody = function (path) {
+        var body;
+        try { body = this.actual.json(); } catch (e) { return this.assert(false, "expected response body to be a valid json", ""); }
+        if (arguments.length > 0) {
+            return this.assert(body !== null && typeof body === "object" && path in body, "expected response body to have property " + path, "expected response body to not have property " + path);
+        }
+        return this.assert(true, "", "expected response body to not be a valid json");
+    };
+    getter("success", function () { var c = this.actual.code; return this.assert(c >= 200 && c < 300, "expected response code to be 2XX but got " + c, "expected response code to not be 2XX"); });
+    getter("error", function () { var c = this.actual.code; return this.assert(c >= 400, "expected response code to be 4XX or 5XX but got " + c, "expected response code to not be 4XX or 5XX"); });
+
+    var response = null;
+    if (ctx.response) {
+        response = {
+            code: ctx.response.code,
+            status: ctx.response.status,
+            responseTime: ctx.response.responseTime,
+            headers: headerList(ctx.response.headers, false),
+            text: function () { return ctx.response.body; },
+            json: function () { return JSON.parse(ctx.response.body); },
+        };
+        Object.defineProperty(response, "to", { get: function () { return new Assertion(response); } });
+    }
+
+    function test(name, fn) {
+        try {
+            fn();
+            ctx.result(String(name), true, "");
+        } catch (e) {
+            ctx.result(String(name), false, e && e.message !== undefined ? String(e.message) : String(e));
+        }
+    }
+    test.skip = function () {};
+
+    return {
+        info: { eventName: ctx.eventName, requestName: ctx.requestName },
+        environment: variableScope(ctx.environment),
+        collectionVariables: variableScope(ctx.collectionVariables),
+        iterationData: ctx.iterationData,
+        variables: { get: function (key) { return ctx.variable(key); }, has: function (key) { return ctx.variable(key) !== undefined; } },
+        request: readOnly({
+            method: ctx.request.method,
+            url: readOnly({ toString: function () { return ctx.request.url; } }, "pm.request.url"),
+            headers: headerList(ctx.request.headers, true),
+            body: readOnly({ raw: ctx.request.body, toString: function () { return ctx.request.body; } }, "pm.request.body"),
+        }, "pm.request"),
+        response: response,
+        test: test,
+        expect: function (actual) { return new Assertion(actual); },
+    };
+})(__ctx);
+
+var tests = {};
+var console = { log: __ctx.log, info: __ctx.log, warn: __ctx.log, error: __ctx.log };
+
+// legacy globals
+var responseBody = __ctx.response ? __ctx.response.body : undefined;
+var responseCode = __ctx.response ? { code: __ctx.response.code, name: __ctx.response.status } : undefined;
+var responseTime = __ctx.response ? __ctx.response.responseTime : undefined;
@@ -0,0 +1,281 @@
+package scriptutil
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/dop251/goja"
+	"github.com/joakim-ribier/gcli-4postman/internal/postman"
+)
+
+// Timeout is the maximum duration of a script execution.
+var Timeout = 10 * time.Second
+
+//go:embed pm.js
+var prelude string
+
+// Request is the API request exposed to the scripts ({pm.request}).
+type Request struct {
+	Name   string
+	Method string
+	Url    string
+	Body   string
+	Header postman.Headers
+}
+
+// Response is the API response exposed to the test scripts ({pm.response}).
+type Response struct {
+	Code         int
+	Status       string
+	TimeInMillis int64
+	Header       http.Header
+	Body         []byte
+}
+
+// Sandbox is the context shared by the scripts of a request execution,
+// the scripts can update the {Env}, the collection {Variables} (and the {Scope}) and the {Request} headers.
+type Sandbox struct {
+	Env       *postman.Env
+	Variables *postman.Variables
+	Params    []postman.Param
+	Scope     postman.Variables
+	Data      postman.IterationData
+
+	Request  Request
+	Response *Response
+
+	Log func(string)
+}
+
+// Run runs the {listen} ("prerequest" or "test") {scripts} and returns the tests results,
+// a script which cannot be executed is returned as a failed result.
+func (s *Sandbox) Run(listen string, scripts []string) postman.TestResults {
+	results := postman.TestResults{}
+	for i, script := range scripts {
+		if err := s.run(listen, script, &results); err != nil {
+			results = append(results, postman.TestResult{
+				Name:  fmt.Sprintf("%s script #%d", listen, i+1),
+				Error: err.Error(),
+			})
+		}
+	}
+	return results
+}
+
+// run runs the {script} in a new JS runtime.
+func (s *Sandbox) run(listen, script string, results *postman.TestResults) error {
+	vm := goja.New()
+
+	timer := time.AfterFunc(Timeout, func() {
+		vm.Interrupt(fmt.Sprintf("script timeout (%s)", Timeout))
+	})
+	defer timer.Stop()
+
+	if err := vm.Set("__ctx", s.newContext(vm, listen, results)); err != nil {
+		return err
+	}
+	if _, err := vm.RunString(prelude); err != nil {
+		return err
+	}
+	if _, err := vm.RunString(script); err != nil {
+		return err
+	}
+
+	// legacy syntax: tests["name"] = expression;
+	if tests := vm.Get("tests"); tests != nil {
+		if o := tests.ToObject(vm); o != nil {
+			for _, key := range o.Keys() {
+				passed := o.Get(key).ToBoolean()
+				*results = append(*results, postman.TestResult{Name: key, Passed: passed})
+			}
+		}
+	}
+	return nil
+}
+
+// newContext builds the native bindings used by the "pm" prelude.
+func (s *Sandbox) newContext(vm *goja.Runtime, listen string, results *postman.TestResults) map[string]any {
+	ctx := map[string]any{
+		"eventName":   listen,
+		"requestName": s.Request.Name,
+		"environment": s.newEnvScope(),
+		"collectionVariables": map[string]any{
+			"has": func(key string) bool {
+				return slices.ContainsFunc(*s.Variables, func(v postman.Variable) bool { return v.Key == key && !v.Disabled })
+			},
+			"get": func(key string) string {
+				if i := slices.IndexFunc(*s.Variables, func(v postman.Variable) bool { return v.Key == key && !v.Disabled }); i > -1 {
+					return (*s.Variables)[i].Value
+				}
+				return ""
+			},
+			"set": func(key, value string) {
+				s.Variables.Set(key, value)
+				s.Scope.Set(key, value)
+			},
+			"unset": func(key string) {
+				s.Variables.Unset(key)
+				s.Scope.Unset(key)
+			},
+			"toObject": func() map[string]string {
+				values := map[string]string{}
+				for _, v := range s.Variables.Enabled() {
+					values[v.Key] = v.Value
+				}
+				return values
+			},
+		},
+		"variable": func(key string) goja.Value {
+			raw := "{{" + key + "}}"
+			if value := postman.Resolve(raw, s.Env, s.Params, s.Scope); value != raw {
+				return vm.ToValue(value)
+			}
+			return goja.Undefined()
+		},
+		"request": map[string]any{
+			"method":  s.Request.Method,
+			"url":     s.Request.Url,
+			"body":    s.Request.Body,
+			"headers": s.newRequestHeaders(),
+		},
+		"iterationData": map[string]any{
+			"has": func(key string) bool {
+				_, ok := s.Data[key]
+				return ok
+			},
+			"get": func(key string) goja.Value {
+				if value, ok := s.Data[key]; ok {
+					return vm.ToValue(value)
+				}
+				return goja.Undefined()
+			},
+			"toObject": func() map[string]string {
+				values := map[string]string{}
+				for key, value := range s.Data {
+					values[key] = value
+				}
+				return values
+			},
+		},
+		"response": goja.Null(),
+		"result": func(name string, passed bool, err string) {
+			*results = append(*results, postman.TestResult{Name: name, Passed: passed, Error: err})
+		},
+		"log": func(call goja.FunctionCall) goja.Value {
+			if s.Log != nil {
+				s.Log(strings.Join(toStrings(call.Arguments), " "))
+			}
+			return goja.Undefined()
+		},
+	}
+
+	if s.Response != nil {
+		ctx["response"] = map[string]any{
+			"code":         s.Response.Code,
+			"status":       s.Response.Status,
+			"responseTime": s.Response.TimeInMillis,
+			"body":         string(s.Response.Body),
+			"headers": map[string]any{
+				"all": func() []any {
+					headers := []any{}
+					for key, values := range s.Response.Header {
+						for _, value := range values {
+							headers = append(headers, map[string]any{"key": key, "value": value})
+						}
+					}
+					return headers
+				},
+			},
+		}
+	}
+
+	return ctx
+}
+
+// newEnvScope builds the {pm.environment} bindings.
+func (s *Sandbox) newEnvScope() map[string]any {
+	find := func(key string) int {
+		return slices.IndexFunc(s.Env.Params, func(p postman.EnvParam) bool { return p.Key == key })
+	}
+	return map[string]any{
+		"has": func(key string) bool {
+			return find(key) > -1
+		},
+		"get": func(key string) string {
+			if i := find(key); i > -1 {
+				return s.Env.Params[i].Value
+			}
+			return ""
+		},
+		"set": func(key, value string) {
+			s.Env.Set(key, value)
+		},
+		"unset": func(key string) {
+			s.Env.Unset(key)
+		},
+		"toObject": func() map[string]string {
+			values := map[string]string{}
+			for _, p := range s.Env.Params {
+				values[p.Key] = p.Value
+			}
+			return values
+		},
+	}
+}
+
+// newRequestHeaders builds the {pm.request.headers} bindings.
+func (s *Sandbox) newRequestHeaders() map[string]any {
+	matches := func(key string) func(h postman.Header) bool {
+		return func(h postman.Header) bool { return strings.EqualFold(h.Key, key) }
+	}
+	return map[string]any{
+		"all": func() []any {
+			headers := []any{}
+			for _, h := range s.Request.Header.Enabled() {
+				headers = append(headers, map[string]any{"key": h.Key, "value": h.Value})
+			}
+			return headers
+		},
+		"add": func(key, value string) {
+			s.Request.Header = append(slices.Clone(s.Request.Header), postman.Header{Key: key, Value: value})
+		},
+		"upsert": func(key, value string) {
+			if i := slices.IndexFunc(s.Request.Header, matches(key)); i > -1 {
+				s.Request.Header = slices.Clone(s.Request.Header)
+				s.Request.Header[i].Value = value
+				s.Request.Header[i].Disabled = false
+			} else {
+				s.Request.Header = append(slices.Clone(s.Request.Header), postman.Header{Key: key, Value: value})
+			}
+		},
+		"remove": func(key string) {
+			s.Request.Header = slices.DeleteFunc(slices.Clone(s.Request.Header), matches(key))
+		},
+	}
+}
+
+// toStrings converts the JS {values} to strings (the objects as JSON).
+func toStrings(values []goja.Value) []string {
+	out := []string{}
+	for _, value := range values {
+		if o, ok := value.Export().(map[string]any); ok {
+			if data, err := json.Marshal(o); err == nil {
+				out = append(out, string(data))
+				continue
+			}
+		}
+		if a, ok := value.Export().([]any); ok {
+			if data, err := json.Marshal(a); err == nil {
+				out = append(out, string(data))
+				continue
+			}
+		}
+		out = append(out, value.String())
+	}
+	return out
+}
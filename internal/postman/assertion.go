@@ -0,0 +1,123 @@
+package postman
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/tidwall/gjson"
+)
+
+// the assertion operators (the longest first)
+var assertionOperators = []string{"==", "!=", ">=", "<=", "!~", "=", ">", "<", "~"}
+
+// Assert evaluates the {expression} on the response and returns the result.
+//
+// The expression is "{subject}{operator}{expected}" where the subject is "status", "time" (ms), "size",
+// "body", "header:{key}" or a {tidwall/gjson} path on the body, and the operator is one of
+// "=" (or "=="), "!=", ">", ">=", "<", "<=", "~" (regex) or "!~". Without operator, the subject must exist.
+func (c CollectionHistoryItem) Assert(expression string) TestResult {
+	subject, operator, expected := splitAssertion(expression)
+
+	actual, exists := c.getAssertionSubject(subject)
+	if !exists {
+		return TestResult{Name: expression, Error: fmt.Sprintf("{%s} does not exist", subject)}
+	}
+	if operator == "" {
+		return TestResult{Name: expression, Passed: true}
+	}
+
+	passed, err := compare(actual, operator, expected)
+	if err != nil {
+		return TestResult{Name: expression, Error: err.Error()}
+	}
+	if !passed {
+		return TestResult{Name: expression, Error: fmt.Sprintf("expected {%s} %s {%s} but got {%s}", subject, operator, expected, actual)}
+	}
+	return TestResult{Name: expression, Passed: true}
+}
+
+// getAssertionSubject returns the value of the {subject} and {true} if it exists.
+func (c CollectionHistoryItem) getAssertionSubject(subject string) (string, bool) {
+	switch subject {
+	case "status":
+		code, _, _ := strings.Cut(c.Status, " ")
+		return code, code != ""
+	case "time":
+		return strconv.FormatInt(c.TimeInMillis, 10), true
+	case "size":
+		return strconv.Itoa(c.GetSize()), true
+	case "body":
+		return string(c.Data), true
+	}
+	if key, found := strings.CutPrefix(subject, "header:"); found {
+		values := c.Header.Values(key)
+		return strings.Join(values, ", "), len(values) > 0
+	}
+	result := gjson.GetBytes(c.Data, subject)
+	return result.String(), result.Exists()
+}
+
+// splitAssertion splits the {expression} on the first operator found outside
+// of the {tidwall/gjson} queries ("data.#(age>40).name=Bob").
+func splitAssertion(expression string) (string, string, string) {
+	depth, quoted := 0, false
+	for i := 0; i < len(expression); i++ {
+		switch c := expression[i]; {
+		case c == '"':
+			quoted = !quoted
+		case quoted:
+		case c == '(' || c == '[' || c == '{':
+			depth++
+		case c == ')' || c == ']' || c == '}':
+			depth--
+		case depth == 0:
+			for _, operator := range assertionOperators {
+				if strings.HasPrefix(expression[i:], operator) {
+					return strings.TrimSpace(expression[:i]), operator, strings.TrimSpace(expression[i+len(operator):])
+				}
+			}
+		}
+	}
+	return strings.TrimSpace(expression), "", ""
+}
+
+// compare compares the {actual} value with the {expected} one (as numbers if possible).
+func compare(actual, operator, expected string) (bool, error) {
+	switch operator {
+	case "~", "!~":
+		r, err := regexp.Compile(expected)
+		if err != nil {
+			return false, fmt.Errorf("{%s} is not a valid regex", expected)
+		}
+		return r.MatchString(actual) == (operator == "~"), nil
+	}
+
+	a, errA := strconv.ParseFloat(actual, 64)
+	e, errE := strconv.ParseFloat(expected, 64)
+	if errA == nil && errE == nil {
+		switch operator {
+		case "=", "==":
+			return a == e, nil
+		case "!=":
+			return a != e, nil
+		case ">":
+			return a > e, nil
+		case ">=":
+			return a >= e, nil
+		case "<":
+			return a < e, nil
+		case "<=":
+			return a <= e, nil
+		}
+	}
+
+	switch operator {
+	case "=", "==":
+		return actual == expected, nil
+	case "!=":
+		return actual != expected, nil
+	}
+	return false, fmt.Errorf("{%s} or {%s} is not a number", actual, expected)
+}
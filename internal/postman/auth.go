@@ -0,0 +1,117 @@
+package postman
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/joakim-ribier/go-utils/pkg/slicesutil"
+)
+
+const (
+	AuthNoAuth  = "noauth"
+	AuthInherit = "inherit"
+	AuthBasic   = "basic"
+	AuthBearer  = "bearer"
+	AuthApiKey  = "apikey"
+	AuthDigest  = "digest"
+	AuthOAuth2  = "oauth2"
+	AuthAwsV4   = "awsv4"
+)
+
+type Auth struct {
+	Type   string     `json:"type"`
+	Basic  AuthValues `json:"basic,omitempty"`
+	Bearer AuthValues `json:"bearer,omitempty"`
+	ApiKey AuthValues `json:"apikey,omitempty"`
+	Digest AuthValues `json:"digest,omitempty"`
+	OAuth2 AuthValues `json:"oauth2,omitempty"`
+	AwsV4  AuthValues `json:"awsv4,omitempty"`
+	Extra  Extra      `json:"-"`
+}
+
+type AuthValues []AuthValue
+
+// AuthValue is a Postman auth attribute, the {Value} can be a string, a boolean or a number.
+type AuthValue struct {
+	Key   string `json:"key"`
+	Value any    `json:"value"`
+	Type  string `json:"type,omitempty"`
+}
+
+// GetValue returns the {Value} as a string.
+func (a AuthValue) GetValue() string {
+	if a.Value == nil {
+		return ""
+	}
+	return fmt.Sprint(a.Value)
+}
+
+// GetValues returns the attributes of the auth {Type}.
+func (a Auth) GetValues() AuthValues {
+	switch strings.ToLower(a.Type) {
+	case AuthBasic:
+		return a.Basic
+	case AuthBearer:
+		return a.Bearer
+	case AuthApiKey:
+		return a.ApiKey
+	case AuthDigest:
+		return a.Digest
+	case AuthOAuth2:
+		return a.OAuth2
+	case AuthAwsV4:
+		return a.AwsV4
+	default:
+		return AuthValues{}
+	}
+}
+
+// Get returns the {key} attribute value of the auth {Type} using the provided context (env, params and scope).
+func (a Auth) Get(key string, env *Env, params []Param, scope Variables) string {
+	if value := slicesutil.FindT(a.GetValues(), func(av AuthValue) bool { return av.Key == key }); value != nil {
+		return replaceRawWithParams(value.GetValue(), env, params, scope)
+	}
+	return ""
+}
+
+// GetOrElse returns the {key} attribute value or {orIsEmpty} if it does not exist or is empty.
+func (a Auth) GetOrElse(key, orIsEmpty string, env *Env, params []Param, scope Variables) string {
+	if value := a.Get(key, env, params, scope); value != "" {
+		return value
+	}
+	return orIsEmpty
+}
+
+// GetQueryParams returns the names of the query params added by the auth (api key "in" query or OAuth2 token "addTokenTo" query params).
+func (a Auth) GetQueryParams(env *Env, params []Param, scope Variables) []string {
+	switch strings.ToLower(a.Type) {
+	case AuthApiKey:
+		if a.GetOrElse("in", "header", env, params, scope) == "query" {
+			return []string{a.Get("key", env, params, scope)}
+		}
+	case AuthOAuth2:
+		if a.GetOrElse("addTokenTo", "header", env, params, scope) == "queryParams" {
+			return []string{"access_token"}
+		}
+	}
+	return nil
+}
+
+// IsInherited returns {true} if the auth is inherited from the parent (folder or collection).
+func (a Auth) IsInherited() bool {
+	return a.Type == "" || strings.EqualFold(a.Type, AuthInherit)
+}
+
+// GetAuthBasicCredential returns the couple {username}/{password} for basic authentication type.
+func (a Auth) GetAuthBasicCredential(env *Env, params []Param, scope Variables) (string, string) {
+	if a.Type != AuthBasic {
+		return "", ""
+	}
+	return a.Get("username", env, params, scope), a.Get("password", env, params, scope)
+}
+
+func (a Auth) extractParams(extract func(string) []string) []string {
+	return slicesutil.FlatTransformT[AuthValue, string](a.GetValues(), func(av AuthValue) ([]string, error) {
+		return extract(av.GetValue()), nil
+	})
+}
@@ -0,0 +1,208 @@
+package postman
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/joakim-ribier/go-utils/pkg/slicesutil"
+)
+
+const (
+	BodyModeRaw        = "raw"
+	BodyModeUrlEncoded = "urlencoded"
+	BodyModeFormData   = "formdata"
+	BodyModeFile       = "file"
+	BodyModeGraphQL    = "graphql"
+)
+
+type Body struct {
+	Mode       string       `json:"mode,omitempty"`
+	Raw        string       `json:"raw,omitempty"`
+	Options    *BodyOptions `json:"options,omitempty"`
+	UrlEncoded BodyParams   `json:"urlencoded,omitempty"`
+	FormData   BodyParams   `json:"formdata,omitempty"`
+	File       *BodyFile    `json:"file,omitempty"`
+	GraphQL    *BodyGraphQL `json:"graphql,omitempty"`
+	Extra      Extra        `json:"-"`
+}
+
+type BodyOptions struct {
+	Raw   BodyRawOptions `json:"raw"`
+	Extra Extra          `json:"-"`
+}
+
+type BodyRawOptions struct {
+	Language string `json:"language,omitempty"`
+	Extra    Extra  `json:"-"`
+}
+
+type BodyParams []BodyParam
+
+// BodyParam is an urlencoded or a formdata field, the {Src} of a "file" formdata field can be a path or a list of paths.
+type BodyParam struct {
+	Key         string `json:"key"`
+	Value       string `json:"value,omitempty"`
+	Type        string `json:"type,omitempty"`
+	Src         any    `json:"src,omitempty"`
+	ContentType string `json:"contentType,omitempty"`
+	Disabled    bool   `json:"disabled,omitempty"`
+	Extra       Extra  `json:"-"`
+}
+
+type BodyFile struct {
+	Src   string `json:"src"`
+	Extra Extra  `json:"-"`
+}
+
+type BodyGraphQL struct {
+	Query     string `json:"query"`
+	Variables string `json:"variables,omitempty"`
+	Extra     Extra  `json:"-"`
+}
+
+// Enabled returns the fields which are not disabled.
+func (b BodyParams) Enabled() BodyParams {
+	return slicesutil.FilterT(b, func(param BodyParam) bool {
+		return !param.Disabled
+	})
+}
+
+// IsFile returns {true} if the formdata field is a file.
+func (b BodyParam) IsFile() bool {
+	return b.Type == "file"
+}
+
+// GetSrc returns the file paths of the formdata field.
+func (b BodyParam) GetSrc() []string {
+	switch src := b.Src.(type) {
+	case string:
+		return []string{src}
+	case []any:
+		return slicesutil.TransformT[any, string](src, func(v any) (*string, error) {
+			s := fmt.Sprint(v)
+			return &s, nil
+		})
+	default:
+		return []string{}
+	}
+}
+
+// GetMode returns the body mode ("raw" by default).
+func (u Body) GetMode() string {
+	if u.Mode == "" {
+		return BodyModeRaw
+	}
+	return u.Mode
+}
+
+// GetContentType returns the "Content-Type" to send with the body
+// (the "multipart/form-data" one is built with its boundary during the request call).
+func (u Body) GetContentType() string {
+	switch u.GetMode() {
+	case BodyModeUrlEncoded:
+		return "application/x-www-form-urlencoded"
+	case BodyModeFormData:
+		return "multipart/form-data"
+	case BodyModeFile:
+		return "application/octet-stream"
+	case BodyModeGraphQL:
+		return "application/json"
+	default:
+		if u.Options == nil {
+			return "application/json"
+		}
+		switch strings.ToLower(u.Options.Raw.Language) {
+		case "text":
+			return "text/plain"
+		case "javascript":
+			return "application/javascript"
+		case "html":
+			return "text/html"
+		case "xml":
+			return "application/xml"
+		default:
+			return "application/json"
+		}
+	}
+}
+
+// IsJson returns {true} if the body is a json document.
+func (u Body) IsJson() bool {
+	return u.GetContentType() == "application/json"
+}
+
+// Get builds the API {body} using the provided context (env, params and scope),
+// the files of the "formdata" and "file" modes are represented by "@{path}".
+func (u Body) Get(env *Env, params []Param, scope Variables) string {
+	resolve := func(raw string) string {
+		return replaceRawWithParams(raw, env, params, scope)
+	}
+
+	switch u.GetMode() {
+	case BodyModeUrlEncoded:
+		values := url.Values{}
+		for _, param := range u.UrlEncoded.Enabled() {
+			values.Add(resolve(param.Key), resolve(param.Value))
+		}
+		return values.Encode()
+	case BodyModeFormData:
+		var fields []string
+		for _, param := range u.FormData.Enabled() {
+			if param.IsFile() {
+				for _, src := range param.GetSrc() {
+					fields = append(fields, resolve(param.Key)+"=@"+resolve(src))
+				}
+			} else {
+				fields = append(fields, resolve(param.Key)+"="+resolve(param.Value))
+			}
+		}
+		return strings.Join(fields, "\n")
+	case BodyModeFile:
+		if u.File == nil {
+			return ""
+		}
+		return "@" + resolve(u.File.Src)
+	case BodyModeGraphQL:
+		if u.GraphQL == nil {
+			return ""
+		}
+		return u.GraphQL.get(resolve)
+	default:
+		return resolve(u.Raw)
+	}
+}
+
+// get builds the GraphQL json document {"query": "...", "variables": {...}}.
+func (g BodyGraphQL) get(resolve func(string) string) string {
+	document := map[string]any{"query": resolve(g.Query)}
+	if variables := strings.TrimSpace(resolve(g.Variables)); variables != "" {
+		if json.Valid([]byte(variables)) {
+			document["variables"] = json.RawMessage(variables)
+		} else {
+			document["variables"] = variables
+		}
+	}
+	bytes, _ := json.Marshal(document)
+	return string(bytes)
+}
+
+func (u Body) extractParams(extract func(string) []string) []string {
+	out := extract(u.Raw)
+	for _, param := range append(u.UrlEncoded.Enabled(), u.FormData.Enabled()...) {
+		out = append(out, extract(param.Key)...)
+		out = append(out, extract(param.Value)...)
+		for _, src := range param.GetSrc() {
+			out = append(out, extract(src)...)
+		}
+	}
+	if u.File != nil {
+		out = append(out, extract(u.File.Src)...)
+	}
+	if u.GraphQL != nil {
+		out = append(out, extract(u.GraphQL.Query)...)
+		out = append(out, extract(u.GraphQL.Variables)...)
+	}
+	return out
+}
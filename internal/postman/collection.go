@@ -1,6 +1,7 @@
 package postman
 
 import (
+	"encoding/json"
 	"fmt"
 	"regexp"
 	"slices"
@@ -11,13 +12,22 @@ import (
 )
 
 type Info struct {
-	Name string
+	PostmanId string `json:"_postman_id,omitempty"`
+	Name      string `json:"name"`
+	Schema    string `json:"schema,omitempty"`
+	Extra     Extra  `json:"-"`
 }
 
 type Collection struct {
-	Info     Info
-	Items    Items    `json:"item"`
-	Metadata Metadata // compute date
+	Info      Info      `json:"info"`
+	Items     Items     `json:"item"`
+	Variables Variables `json:"variable,omitempty"`
+	Auth      *Auth     `json:"auth,omitempty"`
+	Events    Events    `json:"event,omitempty"`
+	Metadata  Metadata  `json:"-"` // compute date
+	Globals   Variables `json:"-"` // workspace then home globals (not part of the collection)
+	Current   Variables `json:"-"` // collection variables updated by the scripts (in memory only, never saved)
+	Extra     Extra     `json:"-"`
 }
 
 type Metadata struct {
@@ -28,46 +38,60 @@ type Items []Item
 type Headers []Header
 
 type Item struct {
-	Name    string
-	Request Request `json:"request,omitempty"`
-	Items   Items   `json:"item,omitempty"`
+	Name      string    `json:"name"`
+	Request   Request   `json:"request,omitempty"`
+	Items     Items     `json:"item,omitempty"`
+	Variables Variables `json:"variable,omitempty"`
+	Auth      *Auth     `json:"auth,omitempty"` // folder auth
+	Events    Events    `json:"event,omitempty"`
+	Scope     Variables `json:"-"` // compute data (collection and parent folders variables)
+	Inherited Events    `json:"-"` // compute data (collection and parent folders events)
+	Extra     Extra     `json:"-"`
 }
 
 type Request struct {
-	Method string
+	Method string  `json:"method"`
 	Header Headers `json:"header,omitempty"`
-	Url    Url
-	Auth   Auth
-	Body   Body
+	Url    Url     `json:"url"`
+	Auth   Auth    `json:"auth"`
+	Body   Body    `json:"body"`
+	Extra  Extra   `json:"-"`
 }
 
 type Header struct {
-	Key   string
-	Value string
+	Key      string `json:"key"`
+	Value    string `json:"value"`
+	Disabled bool   `json:"disabled,omitempty"`
+	Extra    Extra  `json:"-"`
 }
 
-type Url struct {
-	Raw  string
-	Path []string
+type Param struct {
+	Key   string
+	Value string
 }
 
-type Auth struct {
-	Type  string
-	Basic []AuthBasicValue
+// IsPathVariable returns {true} if the param is an url path variable (":id").
+func (p Param) IsPathVariable() bool {
+	return strings.HasPrefix(p.Key, ":")
 }
 
-type AuthBasicValue struct {
-	Key   string
-	Value string
-}
+type Variables []Variable
 
-type Body struct {
-	Raw string
+type Variable struct {
+	Key      string          `json:"key"`
+	Value    string          `json:"value"`
+	Disabled bool            `json:"disabled,omitempty"`
+	Extra    Extra           `json:"-"`
+	raw      json.RawMessage `json:"-"` // non-string value (number, boolean...) written back as is
 }
 
-type Param struct {
-	Key   string
-	Value string
+// itemParent is the context (variables and auth) inherited by an item from its parents.
+type itemParent struct {
+	collection Variables
+	folders    Variables
+	globals    Variables
+	auth       *Auth
+	events     Events
 }
 
 type ItemContainsPattern struct {
@@ -85,14 +109,56 @@ func (c Collection) FindByMethod(method string) Items {
 	return findByMethod(c.Items, []Item{}, strings.ToLower(strings.TrimSpace(method)))
 }
 
-// FindItemByLabel finds the item that matches with the {label}.
+// FindItemByLabel finds the item that matches with the {label}, computes its variables {Scope}
+// (collection, parent folders variables then globals), resolves its inherited auth (nearest folder then collection)
+// and gathers its {Inherited} events (collection then parent folders).
 func (c Collection) FindItemByLabel(label string) *Item {
-	return findByLabel(c.Items, label)
+	return findByLabel(c.Items, label, c.newItemParent())
+}
+
+// GetVariables returns the collection variables updated by the scripts if any, the loaded ones otherwise.
+func (c Collection) GetVariables() Variables {
+	if c.Current != nil {
+		return c.Current
+	}
+	return c.Variables
 }
 
-// SortByName sorts collection items by the {name} field.
+// newItemParent builds the context of the collection root items.
+func (c Collection) newItemParent() itemParent {
+	return itemParent{collection: c.GetVariables().Enabled(), folders: Variables{}, globals: c.Globals, auth: c.Auth, events: c.Events}
+}
+
+// child builds the context of the {item} children: its variables (the nearest first), its auth and its events.
+func (p itemParent) child(item Item) itemParent {
+	child := itemParent{
+		collection: p.collection,
+		folders:    append(item.Variables.Enabled(), p.folders...),
+		globals:    p.globals,
+		auth:       p.auth,
+		events:     append(slices.Clone(p.events), item.Events...),
+	}
+	if item.Auth != nil && !item.Auth.IsInherited() {
+		child.auth = item.Auth
+	}
+	return child
+}
+
+// compute computes the {item} scope (the collection variables, the folders ones then the globals),
+// its inherited auth and its inherited events (the collection ones then the folders ones, the farthest first).
+func (p itemParent) compute(item Item) Item {
+	child := p.child(item)
+	item.Scope = append(append(slices.Clone(child.collection), child.folders...), child.globals...)
+	if item.Request.Auth.IsInherited() && child.auth != nil {
+		item.Request.Auth = *child.auth
+	}
+	item.Inherited = p.events
+	return item
+}
+
+// SortByName returns a copy of the collection with the items sorted by the {name} field.
 func (c Collection) SortByName() Collection {
-	c.Items.SortByName()
+	c.Items = c.Items.clone().SortByName()
 	return c
 }
 
@@ -117,89 +183,149 @@ func (i Item) GetLabel() string {
 	return fmt.Sprintf("%s../%s", i.Request.Method, strings.ToLower(strings.ReplaceAll(i.Name, " ", "_")))
 }
 
-// Get builds the header params using the provided context (env and params).
-func (h Headers) Get(env *Env, params []Param) map[string]string {
+// Enable returns a copy of the headers with the disabled {keys} enabled.
+func (h Headers) Enable(keys ...string) Headers {
+	return slicesutil.TransformT[Header, Header](h, func(header Header) (*Header, error) {
+		if slicesutil.ExistT(keys, func(key string) bool { return strings.EqualFold(key, header.Key) }) {
+			header.Disabled = false
+		}
+		return &header, nil
+	})
+}
+
+// GetDisabledKeys returns the keys of the disabled headers.
+func (h Headers) GetDisabledKeys() []string {
+	return slicesutil.TransformT[Header, string](h, func(header Header) (*string, error) {
+		if header.Disabled {
+			return &header.Key, nil
+		}
+		return nil, nil
+	})
+}
+
+// Enabled returns the headers which are not disabled.
+func (h Headers) Enabled() Headers {
+	return slicesutil.FilterT(h, func(header Header) bool {
+		return !header.Disabled
+	})
+}
+
+// Get builds the header params using the provided context (env, params and scope).
+func (h Headers) Get(env *Env, params []Param, scope Variables) map[string]string {
 	var out = make(map[string]string, len(h))
 	for _, header := range h {
-		if v := replaceRawWithParams(header.Value, env, params); v != "--delete" {
+		if header.Disabled {
+			continue
+		}
+		if v := replaceRawWithParams(header.Value, env, params, scope); v != "--delete" {
 			out[header.Key] = v
 		}
 	}
 	return out
 }
 
-// Get builds the API {url} using the provided context (env and params).
-func (u Url) Get(env *Env, params []Param) string {
-	return replaceRawWithParams(u.Raw, env, params)
-}
-
-// GetLongPath builds the API path.
-func (u Url) GetLongPath() string {
-	return strings.Join(u.Path[:], "/")
+// Enabled returns the variables which are not disabled.
+func (v Variables) Enabled() Variables {
+	return slicesutil.FilterT(v, func(variable Variable) bool {
+		return !variable.Disabled
+	})
 }
 
-// GetShortPath builds the short (3 max) API path.
-func (u Url) GetShortPath() string {
-	if len(u.Path) > 3 {
-		return strings.Join(u.Path[len(u.Path)-3:], "/")
+// Set adds (or replaces) the {key} variable with the {value}.
+func (v *Variables) Set(key, value string) {
+	if i := slices.IndexFunc(*v, func(variable Variable) bool { return variable.Key == key }); i > -1 {
+		*v = slices.Clone(*v)
+		(*v)[i].Value = value
+		(*v)[i].Disabled = false
 	} else {
-		return strings.Join(u.Path, "/")
+		*v = append(slices.Clone(*v), Variable{Key: key, Value: value})
 	}
 }
 
-// Get builds the API {body} using the provided context (env and params).
-func (u Body) Get(env *Env, params []Param) string {
-	return replaceRawWithParams(u.Raw, env, params)
+// Unset removes the {key} variable.
+func (v *Variables) Unset(key string) {
+	*v = slices.DeleteFunc(slices.Clone(*v), func(variable Variable) bool { return variable.Key == key })
 }
 
-// replaceRawWithParams finds and replaces params in {raw} by the values using the provided context (params then env).
-func replaceRawWithParams(raw string, env *Env, params []Param) string {
-	for _, param := range params {
-		raw = strings.ReplaceAll(raw, param.Key, param.Value)
+// Resolve finds and replaces the params in {raw} using the provided context (env, params and scope).
+func Resolve(raw string, env *Env, params []Param, scope Variables) string {
+	return replaceRawWithParams(raw, env, params, scope)
+}
+
+// maxResolveDepth is the max depth of the nested variables ({{baseUrl}} = "{{host}}/v1").
+const maxResolveDepth = 10
+
+// replaceRawWithParams finds and replaces params in {raw} by the values using the provided context
+// with the Postman precedence (params, env then scope - collection, folders variables and globals),
+// a value which contains other variables is resolved too (up to {maxResolveDepth} levels).
+func replaceRawWithParams(raw string, env *Env, params []Param, scope Variables) string {
+	return resolveRaw(raw, env, params, scope, nil, 0)
+}
+
+// resolveRaw replaces the variables of the {raw} value, the unknown ones are kept as is
+// and the values are transformed by the {escape} function (if not nil).
+func resolveRaw(raw string, env *Env, params []Param, scope Variables, escape func(string) string, depth int) string {
+	if depth >= maxResolveDepth {
+		return raw
 	}
-	if env != nil {
-		for _, param := range env.Params {
-			key := "{{" + param.Key + "}}"
-			raw = strings.ReplaceAll(raw, key, param.Value)
+	var out strings.Builder
+	for {
+		end := strings.Index(raw, "}}")
+		if end < 0 {
+			break
 		}
+		start := strings.LastIndex(raw[:end], "{{")
+		if start < 0 {
+			out.WriteString(raw[:end+2])
+			raw = raw[end+2:]
+			continue
+		}
+		key := raw[start : end+2]
+		out.WriteString(raw[:start])
+		if value, ok := findValue(key, env, params, scope); ok {
+			value = resolveRaw(value, env, params, scope, nil, depth+1)
+			if escape != nil {
+				value = escape(value)
+			}
+			out.WriteString(value)
+		} else {
+			out.WriteString(key)
+		}
+		raw = raw[end+2:]
 	}
-	return raw
+	out.WriteString(raw)
+	return out.String()
 }
 
-// GetAuthBasicCredential returns the couple {username}/{password} for basic authentication type.
-func (a Auth) GetAuthBasicCredential(env *Env, params []Param) (string, string) {
-	if a.Type != "basic" {
-		return "", ""
-	}
-	username := a.Basic[slices.IndexFunc(a.Basic, func(el AuthBasicValue) bool { return el.Key == "username" })].Value
-	password := a.Basic[slices.IndexFunc(a.Basic, func(el AuthBasicValue) bool { return el.Key == "password" })].Value
-
+// findValue finds the value of the variable {key} ("{{name}}") in the params, the env then the scope.
+func findValue(key string, env *Env, params []Param, scope Variables) (string, bool) {
 	for _, param := range params {
-		username = strings.ReplaceAll(username, param.Key, param.Value)
-		password = strings.ReplaceAll(password, param.Key, param.Value)
+		if !param.IsPathVariable() && param.Key == key {
+			return param.Value, true
+		}
 	}
+	name := strings.TrimSuffix(strings.TrimPrefix(key, "{{"), "}}")
 	if env != nil {
 		for _, param := range env.Params {
-			key := "{{" + param.Key + "}}"
-			username = strings.ReplaceAll(username, key, param.Value)
-			password = strings.ReplaceAll(password, key, param.Value)
+			if param.Key == name {
+				return param.Value, true
+			}
+		}
+	}
+	for _, variable := range scope {
+		if variable.Key == name {
+			return variable.Value, true
 		}
 	}
-	return username, password
+	return "", false
 }
 
 func (i ItemContainsPattern) Contains() bool {
 	return i.Parent || i.Child
 }
 
-func (a Auth) extractParams(extract func(string) []string) []string {
-	return slicesutil.FlatTransformT[AuthBasicValue, string](a.Basic, func(abv AuthBasicValue) ([]string, error) {
-		return extract(abv.Value), nil
-	})
-}
-
 func (h Headers) extractParams(extract func(string) []string) []string {
-	return slicesutil.FlatTransformT[Header, string](h, func(h Header) ([]string, error) {
+	return slicesutil.FlatTransformT[Header, string](h.Enabled(), func(h Header) ([]string, error) {
 		return extract(h.Value), nil
 	})
 }
@@ -211,8 +337,8 @@ func (i Item) GetParams() []string {
 		return r.FindAllString(in, -1)
 	}
 
-	return slicesutil.NewSliceS(extract(i.Request.Body.Raw)).
-		Append(extract(i.Request.Url.Raw)).
+	return slicesutil.NewSliceS(i.Request.Body.extractParams(extract)).
+		Append(i.Request.Url.extractParams(extract)).
 		Append(i.Request.Auth.extractParams(extract)).
 		Append(i.Request.Header.extractParams(extract)).
 		Distinct().
@@ -252,13 +378,14 @@ func sortItemsBy(items []Item, f func(Item) string) {
 	}
 }
 
-// recursive function that finds the item that matchs with the {label}.
-func findByLabel(items Items, label string) *Item {
+// recursive function that finds the item that matchs with the {label} and computes it with its {parent} context.
+func findByLabel(items Items, label string, parent itemParent) *Item {
 	for _, item := range items {
 		if item.GetLabel() == label {
+			item = parent.compute(item)
 			return &item
 		}
-		if i := findByLabel(item.Items, label); i != nil {
+		if i := findByLabel(item.Items, label, parent.child(item)); i != nil {
 			return i
 		}
 	}
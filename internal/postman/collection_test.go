@@ -0,0 +1,51 @@
+package postman
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestResolve(t *testing.T) {
+	env := &Env{Params: []EnvParam{{Key: "host", Value: "http://localhost"}, {Key: "loop", Value: "{{loop}}"}}}
+	scope := Variables{{Key: "baseUrl", Value: "{{host}}/v1"}, {Key: "host", Value: "http://collection"}, {Key: "user", Value: "{{name}}"}}
+	params := []Param{{Key: "{{name}}", Value: "joakim"}}
+
+	tests := []struct {
+		raw  string
+		want string
+	}{
+		{"{{baseUrl}}/users", "http://localhost/v1/users"},
+		{"{{user}}", "joakim"},
+		{"{{unknown}}/{{host}}", "{{unknown}}/http://localhost"},
+		{"{{loop}}", "{{loop}}"},
+		{"{ {{name}} }}", "{ joakim }}"},
+	}
+	for _, test := range tests {
+		if got := Resolve(test.raw, env, params, scope); got != test.want {
+			t.Errorf("Resolve(%q) = %q, want %q", test.raw, got, test.want)
+		}
+	}
+}
+
+func TestCurrentVariables(t *testing.T) {
+	collection := Collection{
+		Items:     Items{{Name: "a", Request: Request{Method: "GET", Url: Url{Raw: "http://h/{{p}}"}}}},
+		Variables: Variables{{Key: "p", Value: "loaded"}},
+	}
+	collection.Current = collection.GetVariables()
+	collection.Current.Set("p", "current")
+
+	item := collection.FindItemByLabel("GET../a")
+	if got := item.Request.Url.Get(nil, nil, item.Scope); got != "http://h/current" {
+		t.Errorf("Get() = %q, want %q", got, "http://h/current")
+	}
+
+	data, err := json.Marshal(collection)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	if !strings.Contains(string(data), `"value":"loaded"`) || strings.Contains(string(data), "current") {
+		t.Errorf("Marshal() = %s, want only the loaded variables", data)
+	}
+}
@@ -0,0 +1,150 @@
+package postman
+
+import (
+	"encoding/json"
+	"slices"
+	"strings"
+
+	"github.com/joakim-ribier/go-utils/pkg/slicesutil"
+)
+
+// AddItem appends the {item} request to the {folder} path ("{folder}/{sub-folder}", the root if empty),
+// the missing folders are created.
+func (c *Collection) AddItem(folder string, item Item) {
+	c.Items = addItem(c.Items, slicesutil.FilterByNonEmpty(strings.Split(folder, "/")), item)
+}
+
+// recursive function that appends the {item} to the {folders} path (the updated items are copied).
+func addItem(items Items, folders []string, item Item) Items {
+	items = slices.Clone(items)
+	if len(folders) == 0 {
+		return append(items, item)
+	}
+	for i := range items {
+		if items[i].Items != nil && items[i].Name == folders[0] {
+			items[i].Items = addItem(items[i].Items, folders[1:], item)
+			return items
+		}
+	}
+	return append(items, Item{Name: folders[0], Items: addItem(Items{}, folders[1:], item)})
+}
+
+// GetItem returns a copy of the {label} request as it is stored in the collection (without the computed scope)
+// and the path of its folder.
+func (c Collection) GetItem(label string) (*Item, string) {
+	return getItem(c.Items, label, "")
+}
+
+// RemoveItem removes the {label} request, returns {false} if it does not exist.
+func (c *Collection) RemoveItem(label string) bool {
+	items, ok := editItem(c.Items, label, func(items Items, i int) Items {
+		return slices.Delete(items, i, i+1)
+	})
+	if ok {
+		c.Items = items
+	}
+	return ok
+}
+
+// UpdateItem updates the {label} request using the {update} function, returns {false} if it does not exist.
+func (c *Collection) UpdateItem(label string, update func(*Item)) bool {
+	items, ok := editItem(c.Items, label, func(items Items, i int) Items {
+		item := cloneItem(items[i])
+		update(&item)
+		items[i] = item
+		return items
+	})
+	if ok {
+		c.Items = items
+	}
+	return ok
+}
+
+// DuplicateItem inserts a copy of the {label} request named {name} just after it, returns {false} if it does not exist.
+func (c *Collection) DuplicateItem(label, name string) bool {
+	items, ok := editItem(c.Items, label, func(items Items, i int) Items {
+		item := cloneItem(items[i])
+		item.Name = name
+		return slices.Insert(items, i+1, item)
+	})
+	if ok {
+		c.Items = items
+	}
+	return ok
+}
+
+// recursive function that finds the {label} request and its folder path.
+func getItem(items Items, label, folder string) (*Item, string) {
+	for _, item := range items {
+		if item.IsRequest() && item.GetLabel() == label {
+			item := cloneItem(item)
+			return &item, folder
+		}
+		if item.Items != nil {
+			if i, f := getItem(item.Items, label, strings.TrimPrefix(folder+"/"+item.Name, "/")); i != nil {
+				return i, f
+			}
+		}
+	}
+	return nil, ""
+}
+
+// recursive function that applies the {edit} function on the items which contain the {label} request
+// (the updated items are copied).
+func editItem(items Items, label string, edit func(items Items, i int) Items) (Items, bool) {
+	for i, item := range items {
+		if item.IsRequest() && item.GetLabel() == label {
+			return edit(slices.Clone(items), i), true
+		}
+		if item.Items != nil {
+			if values, ok := editItem(item.Items, label, edit); ok {
+				items = slices.Clone(items)
+				items[i].Items = values
+				return items, true
+			}
+		}
+	}
+	return items, false
+}
+
+// cloneItem returns a deep copy of the {item} (the nested slices are not shared).
+func cloneItem(item Item) Item {
+	var out Item
+	data, err := json.Marshal(item)
+	if err != nil || json.Unmarshal(data, &out) != nil {
+		return item
+	}
+	return out
+}
+
+// Bake returns a copy of the request with the {params} values written in place of the variables
+// (the env variables are kept): the path variables (":id") become the url variables values
+// and the headers set to "--delete" are removed.
+func (i Item) Bake(params []Param) Item {
+	item := cloneItem(i)
+	item.Scope = nil
+
+	var replacements []string
+	for _, param := range params {
+		if param.IsPathVariable() {
+			item.Request.Url.setVariable(strings.TrimPrefix(param.Key, ":"), param.Value)
+			continue
+		}
+		// the value is written in the JSON request
+		value, _ := json.Marshal(param.Value)
+		replacements = append(replacements, param.Key, string(value[1:len(value)-1]))
+	}
+	if len(replacements) > 0 {
+		var request Request
+		if data, err := json.Marshal(item.Request); err == nil {
+			if err := json.Unmarshal([]byte(strings.NewReplacer(replacements...).Replace(string(data))), &request); err == nil {
+				item.Request = request
+			}
+		}
+	}
+
+	item.Request.Header = slicesutil.FilterT(item.Request.Header, func(header Header) bool {
+		return header.Value != "--delete"
+	})
+	return item
+}
@@ -2,9 +2,12 @@ package postman
 
 import (
 	"fmt"
+	"net/http"
+	"strings"
 	"time"
 
 	"github.com/joakim-ribier/go-utils/pkg/slicesutil"
+	"github.com/tidwall/gjson"
 )
 
 type CollectionHistoryItemsLight []CollectionHistoryItemLight
@@ -14,6 +17,7 @@ type CollectionHistoryItemLight struct {
 	Item       Item
 	Env        *Env
 	ExecutedAt time.Time
+	FileName   string `json:"-"` // name of the loaded history file
 }
 
 type CollectionHistoryItem struct {
@@ -26,8 +30,16 @@ type CollectionHistoryItem struct {
 	Data          []byte
 	ContentLength int64
 
+	Url           string // final url (after redirects)
+	Proto         string
+	Header        http.Header
+	RequestHeader http.Header
+
+	TestResults TestResults
+
 	Env    *Env
 	Params []Param
+	Scope  Variables // computed item variables (not persisted in the collection)
 
 	ExecutedAt time.Time
 }
@@ -45,11 +57,21 @@ func NewCollectionHistoryItem(number int, item Item, status string, timeInMillis
 
 		Env:    env,
 		Params: params,
+		Scope:  item.Scope,
 
 		ExecutedAt: time.Now(),
 	}
 }
 
+// WithHttpDetails adds the HTTP exchange details: final {url}, {proto}, response {header} and sent {requestHeader}.
+func (c CollectionHistoryItem) WithHttpDetails(url, proto string, header, requestHeader http.Header) CollectionHistoryItem {
+	c.Url = url
+	c.Proto = proto
+	c.Header = header
+	c.RequestHeader = requestHeader
+	return c
+}
+
 // GetBody returns the full {Data} or truncated by the {max} limit.
 func (c CollectionHistoryItem) GetData(max int) []byte {
 	if len(c.Data) > max && max != -1 {
@@ -58,6 +80,15 @@ func (c CollectionHistoryItem) GetData(max int) []byte {
 	return c.Data
 }
 
+// Search finds data in the response: the {pattern} is a {tidwall/gjson} path on the body
+// or "header:{key}" to get a response header value.
+func (c CollectionHistoryItem) Search(pattern string) string {
+	if key, found := strings.CutPrefix(pattern, "header:"); found {
+		return c.Header.Get(key)
+	}
+	return gjson.GetBytes(c.Data, pattern).String()
+}
+
 // GetSize returns the size of {Data}.
 func (c CollectionHistoryItem) GetSize() int {
 	if c.Data == nil {
@@ -98,9 +129,13 @@ func (c CollectionHistoryItemsLight) FindByLabel(label string) *CollectionHistor
 	})
 }
 
-// GetNameFile builds the history item filename.
+// BuildNameFile builds the history item filename (unique with the nanoseconds and the item number)
+// or returns the name of the loaded file.
 func (c CollectionHistoryItemLight) BuildNameFile() string {
-	return c.ExecutedAt.Format("2006-01-02_150405") + "_.json"
+	if c.FileName != "" {
+		return c.FileName
+	}
+	return fmt.Sprintf("%s_%d.json", c.ExecutedAt.Format("2006-01-02_150405.000000000"), c.Number)
 }
 
 // SortByExecutedAt sorts collection history items by {executedAt} field.
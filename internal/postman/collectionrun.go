@@ -0,0 +1,105 @@
+package postman
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/joakim-ribier/go-utils/pkg/slicesutil"
+)
+
+type CollectionRunResults []CollectionRunResult
+
+// CollectionRunResult is the result of a request executed by the collection runner.
+type CollectionRunResult struct {
+	Label     string
+	Iteration int
+	Response  *CollectionHistoryItem
+	Error     string
+}
+
+// NewCollectionRunResult builds the result of an executed request from its {response}.
+func NewCollectionRunResult(response CollectionHistoryItem) CollectionRunResult {
+	return CollectionRunResult{Label: response.Item.GetLabel(), Iteration: 1, Response: &response}
+}
+
+// HasFailed returns {true} if the request cannot be called or if one of its tests has failed.
+func (r CollectionRunResult) HasFailed() bool {
+	return r.Error != "" || r.Response == nil || r.Response.TestResults.HasFailed()
+}
+
+// HasFailed returns {true} if at least one of the requests has failed.
+func (r CollectionRunResults) HasFailed() bool {
+	return slicesutil.ExistT(r, func(result CollectionRunResult) bool {
+		return result.HasFailed()
+	})
+}
+
+// GetIterations returns the iterations numbers of the results.
+func (r CollectionRunResults) GetIterations() []int {
+	iterations := []int{}
+	for _, result := range r {
+		if !slices.Contains(iterations, result.Iteration) {
+			iterations = append(iterations, result.Iteration)
+		}
+	}
+	return iterations
+}
+
+// FindByIteration finds the results of the {iteration}.
+func (r CollectionRunResults) FindByIteration(iteration int) CollectionRunResults {
+	return slicesutil.FilterT(r, func(result CollectionRunResult) bool {
+		return result.Iteration == iteration
+	})
+}
+
+// GetFolders returns the folders paths ("{folder}/{sub-folder}") in the collection order.
+func (c Collection) GetFolders() []string {
+	return findFolders(c.Items, "", []string{})
+}
+
+// GetRequests returns the requests (in the collection order) of the {folder} path (all if empty)
+// which match with the {pattern}, each one computed with its own parent folders (scope, auth and events).
+func (c Collection) GetRequests(folder, pattern string) Items {
+	return findRequests(c.Items, "", strings.Trim(folder, "/"), strings.ToLower(strings.TrimSpace(pattern)), c.newItemParent(), Items{})
+}
+
+// clone returns a deep copy of the items tree (to sort it without updating the collection).
+func (items Items) clone() Items {
+	if items == nil {
+		return nil
+	}
+	return slicesutil.TransformT[Item, Item](items, func(item Item) (*Item, error) {
+		item.Items = item.Items.clone()
+		return &item, nil
+	})
+}
+
+// recursive function that returns the folders paths.
+func findFolders(items Items, path string, folders []string) []string {
+	for _, item := range items {
+		if item.Items != nil {
+			folders = append(folders, path+item.Name)
+			folders = findFolders(item.Items, path+item.Name+"/", folders)
+		}
+	}
+	return folders
+}
+
+// recursive function that returns the requests of the {folder} which match with the {pattern},
+// computed with their {parent} context.
+func findRequests(items Items, path, folder, pattern string, parent itemParent, requests Items) Items {
+	for _, item := range items {
+		if item.Items != nil {
+			requests = findRequests(item.Items, path+item.Name+"/", folder, pattern, parent.child(item), requests)
+			continue
+		}
+		if !item.IsRequest() {
+			continue
+		}
+		inFolder := folder == "" || strings.HasPrefix(path, folder+"/")
+		if inFolder && (pattern == "" || strings.Contains(strings.ToLower(item.GetLabel()), pattern)) {
+			requests = append(requests, parent.compute(item))
+		}
+	}
+	return requests
+}
@@ -0,0 +1,43 @@
+package postman
+
+import "testing"
+
+func TestGetRequestsWithSameLabel(t *testing.T) {
+	request := func(url string) Item {
+		return Item{Name: "get user", Request: Request{Method: "GET", Url: Url{Raw: url}}}
+	}
+	folder := func(name, token string) Item {
+		return Item{
+			Name:      name,
+			Items:     Items{request("{{host}}/" + name)},
+			Variables: Variables{{Key: "host", Value: "http://" + name}},
+			Auth:      &Auth{Type: AuthBearer, Bearer: AuthValues{{Key: "token", Value: token}}},
+			Events:    Events{{Listen: EventPreRequest, Script: Script{Exec: "pm.variables.set('folder', '" + name + "')"}}},
+		}
+	}
+	collection := Collection{
+		Items:     Items{folder("v1", "t1"), folder("v2", "t2")},
+		Variables: Variables{{Key: "version", Value: "1"}},
+	}
+
+	requests := collection.GetRequests("", "")
+	if len(requests) != 2 {
+		t.Fatalf("GetRequests() returns %d requests, want 2", len(requests))
+	}
+	for i, name := range []string{"v1", "v2"} {
+		request := requests[i]
+		if got, want := request.Request.Url.Get(nil, nil, request.Scope), "http://"+name+"/"+name; got != want {
+			t.Errorf("requests[%d] url = %q, want %q", i, got, want)
+		}
+		if got, want := request.Request.Auth.Get("token", nil, nil, request.Scope), "t"+name[1:]; got != want {
+			t.Errorf("requests[%d] token = %q, want %q", i, got, want)
+		}
+		if got := request.GetScripts(EventPreRequest); len(got) != 1 || got[0] != "pm.variables.set('folder', '"+name+"')" {
+			t.Errorf("requests[%d] scripts = %q", i, got)
+		}
+	}
+
+	if requests := collection.GetRequests("v2", ""); len(requests) != 1 || requests[0].Request.Url.Raw != "{{host}}/v2" {
+		t.Errorf("GetRequests(v2) = %+v, want the v2 request", requests)
+	}
+}
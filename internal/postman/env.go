@@ -1,6 +1,10 @@
 package postman
 
-import "github.com/gosimple/slug"
+import (
+	"slices"
+
+	"github.com/gosimple/slug"
+)
 
 type EnvParam struct {
 	Key   string
@@ -16,6 +20,21 @@ func (e Env) GetName() string {
 	return slug.Make(e.Name)
 }
 
+// Set adds (or replaces) the {key} param with the {value}.
+func (e *Env) Set(key, value string) {
+	if i := slices.IndexFunc(e.Params, func(p EnvParam) bool { return p.Key == key }); i > -1 {
+		e.Params = slices.Clone(e.Params)
+		e.Params[i].Value = value
+	} else {
+		e.Params = append(slices.Clone(e.Params), EnvParam{Key: key, Value: value})
+	}
+}
+
+// Unset removes the {key} param.
+func (e *Env) Unset(key string) {
+	e.Params = slices.DeleteFunc(slices.Clone(e.Params), func(p EnvParam) bool { return p.Key == key })
+}
+
 func NewEnv() Env {
 	return Env{
 		Name:   "",
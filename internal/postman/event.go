@@ -0,0 +1,78 @@
+package postman
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/joakim-ribier/go-utils/pkg/slicesutil"
+)
+
+const (
+	EventPreRequest = "prerequest"
+	EventTest       = "test"
+)
+
+type Events []Event
+
+type Event struct {
+	Listen   string `json:"listen"`
+	Script   Script `json:"script"`
+	Disabled bool   `json:"disabled,omitempty"`
+	Extra    Extra  `json:"-"`
+}
+
+// Script is a Postman script, the {Exec} can be a list of lines or a simple string.
+type Script struct {
+	Type  string `json:"type,omitempty"`
+	Exec  any    `json:"exec"`
+	Extra Extra  `json:"-"`
+}
+
+type TestResults []TestResult
+
+// TestResult is the result of a test (script or assertion) executed on the response.
+type TestResult struct {
+	Name   string
+	Passed bool
+	Error  string `json:"error,omitempty"`
+}
+
+// GetSource returns the script source code.
+func (s Script) GetSource() string {
+	switch exec := s.Exec.(type) {
+	case string:
+		return exec
+	case []any:
+		return strings.Join(slicesutil.TransformT[any, string](exec, func(line any) (*string, error) {
+			s := fmt.Sprint(line)
+			return &s, nil
+		}), "\n")
+	default:
+		return ""
+	}
+}
+
+// GetScripts returns the source code of the enabled {listen} ("prerequest" or "test") events.
+func (e Events) GetScripts(listen string) []string {
+	return slicesutil.TransformT[Event, string](e, func(event Event) (*string, error) {
+		if event.Listen == listen && !event.Disabled {
+			if source := event.Script.GetSource(); strings.TrimSpace(source) != "" {
+				return &source, nil
+			}
+		}
+		return nil, nil
+	})
+}
+
+// HasFailed returns {true} if at least one of the tests has failed.
+func (t TestResults) HasFailed() bool {
+	return slicesutil.ExistT(t, func(r TestResult) bool {
+		return !r.Passed
+	})
+}
+
+// GetScripts returns the {listen} scripts of the item in the Postman order:
+// the collection ones, the parent folders ones then the request ones.
+func (i Item) GetScripts(listen string) []string {
+	return append(i.Inherited.GetScripts(listen), i.Events.GetScripts(listen)...)
+}
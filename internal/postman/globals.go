@@ -0,0 +1,68 @@
+package postman
+
+import (
+	"encoding/json"
+	"slices"
+
+	"github.com/joakim-ribier/go-utils/pkg/slicesutil"
+)
+
+// Globals are the Postman global variables shared by the collections of a workspace (or of all the workspaces),
+// they have the lowest precedence (after the collection and folders variables).
+type Globals struct {
+	Values GlobalValues `json:"values"`
+}
+
+type GlobalValues []GlobalValue
+
+type GlobalValue struct {
+	Key     string `json:"key"`
+	Value   string `json:"value"`
+	Type    string `json:"type,omitempty"`
+	Enabled bool   `json:"enabled"`
+}
+
+func NewGlobals() Globals {
+	return Globals{
+		Values: GlobalValues{},
+	}
+}
+
+// UnmarshalJSON decodes the global value, it is enabled if the {enabled} field is not provided.
+func (g *GlobalValue) UnmarshalJSON(data []byte) error {
+	type alias GlobalValue
+	value := alias{Enabled: true}
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+	*g = GlobalValue(value)
+	return nil
+}
+
+// Set adds (or replaces) the {key} value (enabled).
+func (g *Globals) Set(key, value string) {
+	if i := slices.IndexFunc(g.Values, func(v GlobalValue) bool { return v.Key == key }); i > -1 {
+		g.Values = slices.Clone(g.Values)
+		g.Values[i].Value = value
+		g.Values[i].Enabled = true
+	} else {
+		g.Values = append(slices.Clone(g.Values), GlobalValue{Key: key, Value: value, Enabled: true})
+	}
+}
+
+// Unset removes the {key} value, returns {false} if it does not exist.
+func (g *Globals) Unset(key string) bool {
+	size := len(g.Values)
+	g.Values = slices.DeleteFunc(slices.Clone(g.Values), func(v GlobalValue) bool { return v.Key == key })
+	return len(g.Values) != size
+}
+
+// Variables returns the enabled values as variables.
+func (g Globals) Variables() Variables {
+	return slicesutil.TransformT[GlobalValue, Variable](g.Values, func(v GlobalValue) (*Variable, error) {
+		if !v.Enabled {
+			return nil, nil
+		}
+		return &Variable{Key: v.Key, Value: v.Value}, nil
+	})
+}
@@ -0,0 +1,94 @@
+package postman
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/joakim-ribier/go-utils/pkg/slicesutil"
+)
+
+// IterationData is a row of a data file (Postman iteration data), the {{column}} variables of an iteration.
+type IterationData map[string]string
+
+// ParseIterationData parses the data file {content}: a JSON array of objects or a CSV with a header line.
+func ParseIterationData(content []byte, isJson bool) ([]IterationData, error) {
+	if isJson {
+		return parseJsonIterationData(content)
+	}
+	return parseCsvIterationData(content)
+}
+
+// ToParams transforms the data to params ("{{column}}" keys) sorted by key.
+func (d IterationData) ToParams() []Param {
+	keys := make([]string, 0, len(d))
+	for key := range d {
+		keys = append(keys, key)
+	}
+	return slicesutil.TransformT[string, Param](slicesutil.Sort(keys), func(key string) (*Param, error) {
+		return &Param{Key: "{{" + key + "}}", Value: d[key]}, nil
+	})
+}
+
+func parseCsvIterationData(content []byte) ([]IterationData, error) {
+	r := csv.NewReader(bytes.NewReader(bytes.TrimPrefix(content, []byte("\xef\xbb\xbf"))))
+	r.TrimLeadingSpace = true
+	records, err := r.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(records) < 2 {
+		return nil, errors.New("the CSV data file must have a header line and at least one row")
+	}
+
+	header := records[0]
+	return slicesutil.TransformT[[]string, IterationData](records[1:], func(record []string) (*IterationData, error) {
+		data := IterationData{}
+		for i, column := range header {
+			if i < len(record) {
+				data[column] = record[i]
+			}
+		}
+		return &data, nil
+	}), nil
+}
+
+func parseJsonIterationData(content []byte) ([]IterationData, error) {
+	var rows []map[string]any
+	if err := json.Unmarshal(content, &rows); err != nil {
+		return nil, fmt.Errorf("the JSON data file must be an array of objects: %w", err)
+	}
+	if len(rows) == 0 {
+		return nil, errors.New("the JSON data file is empty")
+	}
+
+	return slicesutil.TransformT[map[string]any, IterationData](rows, func(row map[string]any) (*IterationData, error) {
+		data := IterationData{}
+		for key, value := range row {
+			switch v := value.(type) {
+			case string:
+				data[key] = v
+			case nil:
+				data[key] = ""
+			default:
+				b, _ := json.Marshal(v)
+				data[key] = string(b)
+			}
+		}
+		return &data, nil
+	}), nil
+}
+
+// AppendTo returns the {params} completed by the data params not already provided (the user params take precedence).
+func (d IterationData) AppendTo(params []Param) []Param {
+	out := slices.Clone(params)
+	for _, param := range d.ToParams() {
+		if !slices.ContainsFunc(params, func(p Param) bool { return p.Key == param.Key }) {
+			out = append(out, param)
+		}
+	}
+	return out
+}
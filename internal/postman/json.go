@@ -0,0 +1,331 @@
+package postman
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// SchemaV21 is the schema of the Postman v2.1 collection format.
+const SchemaV21 = "https://schema.getpostman.com/json/collection/v2.1.0/collection.json"
+
+// Extra keeps the JSON fields which are not modelled (description, response examples, ids...)
+// to write back the Postman data without losing them.
+type Extra map[string]json.RawMessage
+
+// knownFields caches the JSON field names (lowercase) of the types.
+var knownFields sync.Map
+
+// unmarshalWithExtra decodes the {data} into {v} (a pointer to an alias type) and keeps the unknown fields in {extra}.
+func unmarshalWithExtra(data []byte, v any, extra *Extra) error {
+	if err := json.Unmarshal(data, v); err != nil {
+		return err
+	}
+	*extra = getExtra(data, reflect.TypeOf(v).Elem())
+	return nil
+}
+
+// marshalWithExtra encodes the {v} value with the {extra} fields.
+func marshalWithExtra(v any, extra Extra) ([]byte, error) {
+	data, err := json.Marshal(v)
+	if err != nil || len(extra) == 0 {
+		return data, err
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	for key, value := range extra {
+		if _, ok := fields[key]; !ok {
+			fields[key] = value
+		}
+	}
+	return json.Marshal(fields)
+}
+
+// getExtra returns the fields of the JSON object {data} which are not a field of the {t} type.
+func getExtra(data []byte, t reflect.Type) Extra {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil
+	}
+	known := getKnownFields(t)
+	for key := range fields {
+		// the JSON decoder matches the keys case-insensitively
+		if known[strings.ToLower(key)] {
+			delete(fields, key)
+		}
+	}
+	if len(fields) == 0 {
+		return nil
+	}
+	return fields
+}
+
+// getKnownFields returns the JSON field names (lowercase) of the {t} struct type (including the embedded ones).
+func getKnownFields(t reflect.Type) map[string]bool {
+	if v, ok := knownFields.Load(t); ok {
+		return v.(map[string]bool)
+	}
+	out := map[string]bool{}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		if field.Anonymous && name == "" && field.Type.Kind() == reflect.Struct {
+			for key := range getKnownFields(field.Type) {
+				out[key] = true
+			}
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		out[strings.ToLower(name)] = true
+	}
+	knownFields.Store(t, out)
+	return out
+}
+
+// Export encodes the collection in the Postman v2.1 format (with its schema) to be re-imported in Postman.
+func (c Collection) Export() ([]byte, error) {
+	if c.Info.Schema == "" {
+		c.Info.Schema = SchemaV21
+	}
+	if c.Items == nil {
+		c.Items = Items{}
+	}
+	return json.MarshalIndent(c, "", "  ")
+}
+
+// The Postman v2.1 objects keep their unknown fields to be written back losslessly.
+
+func (c *Collection) UnmarshalJSON(data []byte) error {
+	type alias Collection
+	return unmarshalWithExtra(data, (*alias)(c), &c.Extra)
+}
+
+func (c Collection) MarshalJSON() ([]byte, error) {
+	type alias Collection
+	return marshalWithExtra(alias(c), c.Extra)
+}
+
+func (i *Info) UnmarshalJSON(data []byte) error {
+	type alias Info
+	return unmarshalWithExtra(data, (*alias)(i), &i.Extra)
+}
+
+func (i Info) MarshalJSON() ([]byte, error) {
+	type alias Info
+	return marshalWithExtra(alias(i), i.Extra)
+}
+
+// UnmarshalJSON decodes the item, the computed {scope} written by the previous versions is dropped.
+func (i *Item) UnmarshalJSON(data []byte) error {
+	type alias Item
+	if err := unmarshalWithExtra(data, (*alias)(i), &i.Extra); err != nil {
+		return err
+	}
+	delete(i.Extra, "scope")
+	return nil
+}
+
+// MarshalJSON encodes the item, the {request} field is only written for an API request
+// and the {item} field (even empty) for a folder.
+func (i Item) MarshalJSON() ([]byte, error) {
+	type alias Item
+	out := struct {
+		alias
+		Items   *Items   `json:"item,omitempty"`
+		Request *Request `json:"request,omitempty"`
+	}{alias: alias(i)}
+	if i.IsRequest() {
+		out.Request = &i.Request
+	} else {
+		items := i.Items
+		if items == nil {
+			items = Items{}
+		}
+		out.Items = &items
+	}
+	return marshalWithExtra(out, i.Extra)
+}
+
+func (r *Request) UnmarshalJSON(data []byte) error {
+	type alias Request
+	return unmarshalWithExtra(data, (*alias)(r), &r.Extra)
+}
+
+// MarshalJSON encodes the request, the empty {auth} and {body} fields are omitted.
+func (r Request) MarshalJSON() ([]byte, error) {
+	type alias Request
+	out := struct {
+		alias
+		Auth *Auth `json:"auth,omitempty"`
+		Body *Body `json:"body,omitempty"`
+	}{alias: alias(r)}
+	if r.Auth.Type != "" || r.Auth.Extra != nil {
+		out.Auth = &r.Auth
+	}
+	if r.Body.Mode != "" || r.Body.Extra != nil {
+		out.Body = &r.Body
+	}
+	return marshalWithExtra(out, r.Extra)
+}
+
+func (h *Header) UnmarshalJSON(data []byte) error {
+	type alias Header
+	return unmarshalWithExtra(data, (*alias)(h), &h.Extra)
+}
+
+func (h Header) MarshalJSON() ([]byte, error) {
+	type alias Header
+	return marshalWithExtra(alias(h), h.Extra)
+}
+
+// UnmarshalJSON decodes the variable, a non-string {value} (number, boolean...) is used as its JSON text.
+func (v *Variable) UnmarshalJSON(data []byte) error {
+	type alias Variable
+	var in struct {
+		alias
+		Value json.RawMessage `json:"value"`
+	}
+	var extra Extra
+	if err := unmarshalWithExtra(data, &in, &extra); err != nil {
+		return err
+	}
+	*v = Variable(in.alias)
+	v.Extra = extra
+	if len(in.Value) > 0 && json.Unmarshal(in.Value, &v.Value) != nil {
+		v.Value, v.raw = string(in.Value), in.Value
+	}
+	return nil
+}
+
+// MarshalJSON encodes the variable, a non-string {value} is written back with its type
+// as long as it is still a JSON value (not a string).
+func (v Variable) MarshalJSON() ([]byte, error) {
+	out := struct {
+		Key      string `json:"key"`
+		Value    any    `json:"value"`
+		Disabled bool   `json:"disabled,omitempty"`
+	}{Key: v.Key, Value: v.Value, Disabled: v.Disabled}
+	if v.raw != nil && json.Valid([]byte(v.Value)) && !strings.HasPrefix(strings.TrimSpace(v.Value), `"`) {
+		out.Value = json.RawMessage(v.Value)
+	}
+	return marshalWithExtra(out, v.Extra)
+}
+
+// MarshalJSON encodes the url as a simple string if it is not structured (no host, query or variable...),
+// otherwise as an object.
+func (u Url) MarshalJSON() ([]byte, error) {
+	if len(u.Host) == 0 && len(u.Query) == 0 && len(u.Variable) == 0 &&
+		u.Protocol == "" && u.Port == "" && u.Hash == "" && u.Extra == nil {
+		return json.Marshal(u.Raw)
+	}
+	type alias Url
+	return marshalWithExtra(alias(u), u.Extra)
+}
+
+func (u *UrlParam) UnmarshalJSON(data []byte) error {
+	type alias UrlParam
+	return unmarshalWithExtra(data, (*alias)(u), &u.Extra)
+}
+
+func (u UrlParam) MarshalJSON() ([]byte, error) {
+	type alias UrlParam
+	return marshalWithExtra(alias(u), u.Extra)
+}
+
+func (b *Body) UnmarshalJSON(data []byte) error {
+	type alias Body
+	return unmarshalWithExtra(data, (*alias)(b), &b.Extra)
+}
+
+func (b Body) MarshalJSON() ([]byte, error) {
+	type alias Body
+	return marshalWithExtra(alias(b), b.Extra)
+}
+
+func (b *BodyOptions) UnmarshalJSON(data []byte) error {
+	type alias BodyOptions
+	return unmarshalWithExtra(data, (*alias)(b), &b.Extra)
+}
+
+func (b BodyOptions) MarshalJSON() ([]byte, error) {
+	type alias BodyOptions
+	return marshalWithExtra(alias(b), b.Extra)
+}
+
+func (b *BodyRawOptions) UnmarshalJSON(data []byte) error {
+	type alias BodyRawOptions
+	return unmarshalWithExtra(data, (*alias)(b), &b.Extra)
+}
+
+func (b BodyRawOptions) MarshalJSON() ([]byte, error) {
+	type alias BodyRawOptions
+	return marshalWithExtra(alias(b), b.Extra)
+}
+
+func (b *BodyParam) UnmarshalJSON(data []byte) error {
+	type alias BodyParam
+	return unmarshalWithExtra(data, (*alias)(b), &b.Extra)
+}
+
+func (b BodyParam) MarshalJSON() ([]byte, error) {
+	type alias BodyParam
+	return marshalWithExtra(alias(b), b.Extra)
+}
+
+func (b *BodyFile) UnmarshalJSON(data []byte) error {
+	type alias BodyFile
+	return unmarshalWithExtra(data, (*alias)(b), &b.Extra)
+}
+
+func (b BodyFile) MarshalJSON() ([]byte, error) {
+	type alias BodyFile
+	return marshalWithExtra(alias(b), b.Extra)
+}
+
+func (b *BodyGraphQL) UnmarshalJSON(data []byte) error {
+	type alias BodyGraphQL
+	return unmarshalWithExtra(data, (*alias)(b), &b.Extra)
+}
+
+func (b BodyGraphQL) MarshalJSON() ([]byte, error) {
+	type alias BodyGraphQL
+	return marshalWithExtra(alias(b), b.Extra)
+}
+
+func (a *Auth) UnmarshalJSON(data []byte) error {
+	type alias Auth
+	return unmarshalWithExtra(data, (*alias)(a), &a.Extra)
+}
+
+func (a Auth) MarshalJSON() ([]byte, error) {
+	type alias Auth
+	return marshalWithExtra(alias(a), a.Extra)
+}
+
+func (e *Event) UnmarshalJSON(data []byte) error {
+	type alias Event
+	return unmarshalWithExtra(data, (*alias)(e), &e.Extra)
+}
+
+func (e Event) MarshalJSON() ([]byte, error) {
+	type alias Event
+	return marshalWithExtra(alias(e), e.Extra)
+}
+
+func (s *Script) UnmarshalJSON(data []byte) error {
+	type alias Script
+	return unmarshalWithExtra(data, (*alias)(s), &s.Extra)
+}
+
+func (s Script) MarshalJSON() ([]byte, error) {
+	type alias Script
+	return marshalWithExtra(alias(s), s.Extra)
+}
@@ -0,0 +1,63 @@
+package postman
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestMarshalEmptyFolder(t *testing.T) {
+	var collection Collection
+	data := `{"info":{"name":"c"},"item":[{"name":"empty","item":[{"name":"a","request":{"method":"GET","url":"http://h/a"}}]}]}`
+	if err := json.Unmarshal([]byte(data), &collection); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if !collection.RemoveItem("GET../a") {
+		t.Fatal("RemoveItem() = false, want true")
+	}
+	collection.Items = append(collection.Items, Item{Name: "nil"})
+
+	out, err := json.Marshal(collection)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	if strings.Count(string(out), `"item":[]`) != 2 {
+		t.Errorf("Marshal() = %s, want the empty folders with an empty item field", out)
+	}
+
+	var reloaded Collection
+	if err := json.Unmarshal(out, &reloaded); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	for _, folder := range reloaded.Items {
+		if folder.Items == nil || len(folder.Items) != 0 || folder.IsRequest() {
+			t.Errorf("reloaded item %q = %+v, want an empty folder", folder.Name, folder)
+		}
+	}
+}
+
+func TestVariableNonStringValue(t *testing.T) {
+	var variables Variables
+	data := `[{"key":"port","value":8080,"type":"number"},{"key":"debug","value":true},{"key":"host","value":"h"},{"key":"empty"}]`
+	if err := json.Unmarshal([]byte(data), &variables); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if got := Resolve("{{host}}:{{port}}/{{debug}}", nil, nil, variables); got != "h:8080/true" {
+		t.Errorf("Resolve() = %q, want %q", got, "h:8080/true")
+	}
+
+	out, err := json.Marshal(variables)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	want := `[{"key":"port","type":"number","value":8080},{"key":"debug","value":true},{"key":"host","value":"h"},{"key":"empty","value":""}]`
+	if string(out) != want {
+		t.Errorf("Marshal() = %s, want %s", out, want)
+	}
+
+	variables.Set("port", "9090")
+	variables.Set("debug", "yes")
+	if out, _ := json.Marshal(variables[:2]); string(out) != `[{"key":"port","type":"number","value":9090},{"key":"debug","value":"yes"}]` {
+		t.Errorf("Marshal() of the updated values = %s", out)
+	}
+}
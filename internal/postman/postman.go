@@ -33,6 +33,19 @@ type PSTEnvironment struct {
 	Name string
 }
 
+// PSTGlobals is the response of the workspace global variables endpoint.
+type PSTGlobals struct {
+	Variables GlobalValues `json:"variables"`
+}
+
+// ToGlobals maps the API global variables to the local {Globals}.
+func (g PSTGlobals) ToGlobals() Globals {
+	if g.Variables == nil {
+		return NewGlobals()
+	}
+	return Globals{Values: g.Variables}
+}
+
 func (w PSTWorkspaces) Find(workspaceIdOrName string) *PSTWorkspace {
 	return slicesutil.FindT(w.Workspaces, func(w PSTWorkspace) bool {
 		return w.Id == workspaceIdOrName || strings.EqualFold(w.Name, workspaceIdOrName)
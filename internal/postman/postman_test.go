@@ -0,0 +1,52 @@
+package postman
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestPSTGlobalsToGlobals(t *testing.T) {
+	// GET https://api.getpostman.com/workspaces/{workspaceId}/global-variables
+	payload := `{
+  "variables": [
+    {
+      "key": "baseUrl",
+      "type": "default",
+      "value": "https://api.example.com",
+      "enabled": true
+    },
+    {
+      "key": "token",
+      "type": "secret",
+      "value": "s3cr3t",
+      "enabled": false
+    }
+  ]
+}`
+
+	var pstGlobals PSTGlobals
+	if err := json.Unmarshal([]byte(payload), &pstGlobals); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+
+	globals := pstGlobals.ToGlobals()
+	want := GlobalValues{
+		{Key: "baseUrl", Value: "https://api.example.com", Type: "default", Enabled: true},
+		{Key: "token", Value: "s3cr3t", Type: "secret", Enabled: false},
+	}
+	if len(globals.Values) != len(want) {
+		t.Fatalf("ToGlobals() = %+v, want %+v", globals.Values, want)
+	}
+	for i := range want {
+		if globals.Values[i] != want[i] {
+			t.Errorf("ToGlobals()[%d] = %+v, want %+v", i, globals.Values[i], want[i])
+		}
+	}
+	if got := globals.Variables(); len(got) != 1 || got[0].Key != "baseUrl" {
+		t.Errorf("Variables() = %+v, want only baseUrl", got)
+	}
+
+	if globals := (PSTGlobals{}).ToGlobals(); globals.Values == nil || len(globals.Values) != 0 {
+		t.Errorf("ToGlobals() of an empty response = %+v, want no values", globals)
+	}
+}
@@ -0,0 +1,268 @@
+package postman
+
+import (
+	"encoding/json"
+	"net/url"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/joakim-ribier/go-utils/pkg/slicesutil"
+)
+
+type Url struct {
+	Raw      string    `json:"raw"`
+	Protocol string    `json:"protocol,omitempty"`
+	Host     []string  `json:"host,omitempty"`
+	Port     string    `json:"port,omitempty"`
+	Path     []string  `json:"path,omitempty"`
+	Query    UrlParams `json:"query,omitempty"`
+	Variable UrlParams `json:"variable,omitempty"`
+	Hash     string    `json:"hash,omitempty"`
+	Extra    Extra     `json:"-"`
+}
+
+type UrlParams []UrlParam
+
+type UrlParam struct {
+	Key      string `json:"key"`
+	Value    string `json:"value"`
+	Disabled bool   `json:"disabled,omitempty"`
+	Extra    Extra  `json:"-"`
+}
+
+// NewUrl builds the url from the {raw} value.
+func NewUrl(raw string) Url {
+	return Url{Raw: raw, Path: parseRawPath(raw)}
+}
+
+// UnmarshalJSON decodes the Postman url which can be a simple string or a structured object
+// (the {host} can also be a string or a list of segments).
+func (u *Url) UnmarshalJSON(data []byte) error {
+	var raw string
+	if err := json.Unmarshal(data, &raw); err == nil {
+		*u = NewUrl(raw)
+		return nil
+	}
+
+	type alias Url
+	var out struct {
+		alias
+		Host any `json:"host,omitempty"`
+	}
+	if err := json.Unmarshal(data, &out); err != nil {
+		return err
+	}
+
+	*u = Url(out.alias)
+	u.Extra = getExtra(data, reflect.TypeOf(Url{}))
+	if len(u.Host) == 0 && len(u.Path) == 0 {
+		u.Path = parseRawPath(u.Raw)
+	}
+	switch host := out.Host.(type) {
+	case string:
+		u.Host = strings.Split(host, ".")
+	case []any:
+		u.Host = slicesutil.TransformT[any, string](host, func(v any) (*string, error) {
+			if s, ok := v.(string); ok {
+				return &s, nil
+			}
+			return nil, nil
+		})
+	}
+	return nil
+}
+
+// Get builds the API {url} using the provided context (env, params and scope):
+// the path variables (":id") are replaced, the disabled query params are dropped and the values are url-encoded.
+func (u Url) Get(env *Env, params []Param, scope Variables) string {
+	resolve := func(raw string) string {
+		return replaceRawWithParams(raw, env, params, scope)
+	}
+	// the values of a path segment are escaped to not change the url structure ("/", "?"...)
+	resolvePath := func(raw string) string {
+		return resolveRaw(raw, env, params, scope, url.PathEscape, 0)
+	}
+
+	if len(u.Host) == 0 {
+		return u.getFromRaw(resolve, resolvePath, params)
+	}
+
+	base := strings.Join(slicesutil.TransformT[string, string](u.Host, func(s string) (*string, error) {
+		v := resolve(s)
+		return &v, nil
+	}), ".")
+	if u.Port != "" {
+		base += ":" + resolve(u.Port)
+	}
+	if u.Protocol != "" {
+		base = resolve(u.Protocol) + "://" + base
+	} else if !strings.Contains(base, "://") {
+		base = "http://" + base
+	}
+
+	var segments []string
+	for _, segment := range u.Path {
+		segments = append(segments, u.resolvePathSegment(segment, resolve, resolvePath, params))
+	}
+
+	return build(base, segments, u.Query.Enabled().encode(resolve), resolve(u.Hash))
+}
+
+// getFromRaw builds the API {url} from the {Raw} field only,
+// each part is resolved once and the encoded path characters (%2F...) are kept.
+func (u Url) getFromRaw(resolve, resolvePath func(string) string, params []Param) string {
+	raw, fragment, _ := strings.Cut(u.Raw, "#")
+	raw, rawQuery, _ := strings.Cut(raw, "?")
+
+	scheme := ""
+	if before, after, found := strings.Cut(raw, "://"); found {
+		scheme, raw = before+"://", after
+	}
+	host, path, hasPath := strings.Cut(raw, "/")
+
+	var segments []string
+	if hasPath {
+		for _, segment := range strings.Split(path, "/") {
+			segments = append(segments, u.resolvePathSegment(segment, resolve, resolvePath, params))
+		}
+	}
+
+	var query []string
+	for _, value := range strings.Split(rawQuery, "&") {
+		if value == "" {
+			continue
+		}
+		key, v, hasValue := strings.Cut(value, "=")
+		key = url.QueryEscape(resolve(queryUnescape(key)))
+		if hasValue {
+			key += "=" + url.QueryEscape(resolve(queryUnescape(v)))
+		}
+		query = append(query, key)
+	}
+
+	return build(resolve(scheme+host), segments, strings.Join(query, "&"), resolve(fragment))
+}
+
+// build joins the {base} url with the path {segments}, the {query} and the {fragment}
+// and normalizes the result (the raw url is returned if it cannot be parsed or has unresolved variables).
+func build(base string, segments []string, query, fragment string) string {
+	raw := base
+	if segments != nil {
+		raw = strings.TrimSuffix(raw, "/") + "/" + strings.Join(segments, "/")
+	}
+	if query != "" {
+		raw += "?" + query
+	}
+	if fragment != "" {
+		raw += "#" + fragment
+	}
+
+	out, err := url.Parse(raw)
+	if err != nil || strings.Contains(raw, "{{") {
+		// let the HTTP client returns the error
+		return raw
+	}
+	return out.String()
+}
+
+// queryUnescape decodes the query {value} or returns it as is if it is not a valid encoded value.
+func queryUnescape(value string) string {
+	if out, err := url.QueryUnescape(value); err == nil {
+		return out
+	}
+	return value
+}
+
+// resolvePathSegment replaces the path variable (":id") by the value of the param or by the default value
+// or resolves the variables of the segment ({resolvePath}), the values are escaped but not the segment itself.
+func (u Url) resolvePathSegment(segment string, resolve, resolvePath func(string) string, params []Param) string {
+	if !strings.HasPrefix(segment, ":") {
+		return resolvePath(segment)
+	}
+	if param := slicesutil.FindT(params, func(p Param) bool { return p.Key == segment }); param != nil {
+		return url.PathEscape(param.Value)
+	}
+	if variable := slicesutil.FindT(u.Variable, func(p UrlParam) bool { return ":"+p.Key == segment }); variable != nil {
+		return url.PathEscape(resolve(variable.Value))
+	}
+	return segment
+}
+
+// setVariable sets the {value} of the {key} path variable (added if it does not exist).
+func (u *Url) setVariable(key, value string) {
+	u.Variable = slices.Clone(u.Variable)
+	for i := range u.Variable {
+		if u.Variable[i].Key == key {
+			u.Variable[i].Value = value
+			return
+		}
+	}
+	u.Variable = append(u.Variable, UrlParam{Key: key, Value: value})
+}
+
+// GetPathVariables returns the path variables (":id") of the url.
+func (u Url) GetPathVariables() []string {
+	out := slicesutil.TransformT[UrlParam, string](u.Variable, func(p UrlParam) (*string, error) {
+		v := ":" + p.Key
+		return &v, nil
+	})
+	for _, segment := range u.Path {
+		if strings.HasPrefix(segment, ":") && !slicesutil.Exist(out, segment) {
+			out = append(out, segment)
+		}
+	}
+	return out
+}
+
+// GetLongPath builds the API path.
+func (u Url) GetLongPath() string {
+	return strings.Join(u.Path[:], "/")
+}
+
+// GetShortPath builds the short (3 max) API path.
+func (u Url) GetShortPath() string {
+	if len(u.Path) > 3 {
+		return strings.Join(u.Path[len(u.Path)-3:], "/")
+	} else {
+		return strings.Join(u.Path, "/")
+	}
+}
+
+// Enabled returns the params which are not disabled.
+func (p UrlParams) Enabled() UrlParams {
+	return slicesutil.FilterT(p, func(param UrlParam) bool {
+		return !param.Disabled
+	})
+}
+
+// encode builds the url-encoded query keeping the params order.
+func (p UrlParams) encode(resolve func(string) string) string {
+	var out []string
+	for _, param := range p {
+		out = append(out, url.QueryEscape(resolve(param.Key))+"="+url.QueryEscape(resolve(param.Value)))
+	}
+	return strings.Join(out, "&")
+}
+
+func (u Url) extractParams(extract func(string) []string) []string {
+	out := append(extract(u.Raw), u.GetPathVariables()...)
+	for _, param := range append(u.Query.Enabled(), u.Variable...) {
+		out = append(out, extract(param.Key)...)
+		out = append(out, extract(param.Value)...)
+	}
+	return out
+}
+
+// parseRawPath extracts the path segments from the {raw} url.
+func parseRawPath(raw string) []string {
+	if _, after, found := strings.Cut(raw, "://"); found {
+		raw = after
+	}
+	raw, _, _ = strings.Cut(raw, "?")
+	raw, _, _ = strings.Cut(raw, "#")
+	if _, path, found := strings.Cut(raw, "/"); found {
+		return slicesutil.FilterByNonEmpty(strings.Split(path, "/"))
+	}
+	return []string{}
+}
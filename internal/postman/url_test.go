@@ -0,0 +1,31 @@
+package postman
+
+import "testing"
+
+func TestUrlGet(t *testing.T) {
+	scope := Variables{{Key: "host", Value: "http://h"}, {Key: "name", Value: "x/y"}, {Key: "nested", Value: "{{name}}"}}
+
+	tests := []struct {
+		name   string
+		url    Url
+		params []Param
+		want   string
+	}{
+		{"path variable with a slash", NewUrl("http://h/a/:id?x=1"), []Param{{Key: ":id", Value: "x/y"}}, "http://h/a/x%2Fy?x=1"},
+		{"path variable with reserved characters", NewUrl("http://h/a/:id?x=1"), []Param{{Key: ":id", Value: "a/b c?d#e"}}, "http://h/a/a%2Fb%20c%3Fd%23e?x=1"},
+		{"default path variable", Url{Raw: "http://h/a/:id", Variable: UrlParams{{Key: "id", Value: "{{name}}"}}}, nil, "http://h/a/x%2Fy"},
+		{"segment variable", NewUrl("{{host}}/a/{{name}}/b"), nil, "http://h/a/x%2Fy/b"},
+		{"nested segment variable", NewUrl("{{host}}/a/v-{{nested}}"), nil, "http://h/a/v-x%2Fy"},
+		{"encoded template segment", NewUrl("http://h/a%2Fb/c"), nil, "http://h/a%2Fb/c"},
+		{"empty query value", NewUrl("http://h/a?x=&y"), nil, "http://h/a?x=&y"},
+		{"structured url", Url{Host: []string{"{{host}}"}, Path: []string{"a", ":id"}, Query: UrlParams{{Key: "q", Value: "a b"}}}, []Param{{Key: ":id", Value: "1?2"}}, "http://h/a/1%3F2?q=a+b"},
+		{"unresolved base url", NewUrl("{{baseUrl}}/users/:id"), []Param{{Key: ":id", Value: "42"}}, "{{baseUrl}}/users/42"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := test.url.Get(nil, test.params, scope); got != test.want {
+				t.Errorf("Get() = %q, want %q", got, test.want)
+			}
+		})
+	}
+}
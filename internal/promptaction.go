@@ -62,6 +62,17 @@ func HasRightToExecute(p PromptAction, in []string, role string) bool {
 	return false
 }
 
+// FindAllNextEl finds all the values which follow the {option} in the user input {in} (the option can be repeated).
+func FindAllNextEl(in []string, option string) []string {
+	var out []string
+	for i, el := range in {
+		if el == option && i+1 < len(in) {
+			out = append(out, in[i+1])
+		}
+	}
+	return out
+}
+
 // FindPromptActionExecutor finds the prompt action executor {T}.
 func FindPromptActionExecutor[T PromptExecutor](actions []PromptAction) *T {
 	if found := slicesutil.FindT[PromptAction](actions, func(pa PromptAction) bool {
@@ -60,6 +60,7 @@ func (p PromptDisplayCollection) PromptExecutor(in []string) *internal.PromptCal
 	if internal.HasRightToExecute(p, in, internal.APP_MODE) {
 		if p.c.Collection == nil {
 			p.c.Print("WARN", "select a collection before to display it")
+			internal.EXIT_CODE = 1
 			return nil
 		}
 		p.GetPromptExecutor().(promptexecutors.DisplayCollectionExecutor).
@@ -0,0 +1,349 @@
+package promptactions
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/c-bata/go-prompt"
+	"github.com/joakim-ribier/gcli-4postman/internal"
+	"github.com/joakim-ribier/gcli-4postman/internal/pkg/prettyprint"
+	"github.com/joakim-ribier/gcli-4postman/internal/postman"
+	"github.com/joakim-ribier/gcli-4postman/internal/promptexecutors"
+	"github.com/joakim-ribier/gcli-4postman/pkg/logger"
+	"github.com/joakim-ribier/go-utils/pkg/slicesutil"
+)
+
+var (
+	editNewS       = prompt.Suggest{Text: "-new", Description: "add a request to the collection"}
+	editBodyS      = prompt.Suggest{Text: "-body", Description: "edit the body of a request in the $EDITOR"}
+	editRenameS    = prompt.Suggest{Text: "-rename", Description: "rename a request"}
+	editMoveS      = prompt.Suggest{Text: "-move", Description: "move a request to another folder"}
+	editDuplicateS = prompt.Suggest{Text: "-duplicate", Description: "duplicate a request"}
+	editDeleteS    = prompt.Suggest{Text: "-delete", Description: "delete a request"}
+	editIntoS      = prompt.Suggest{Text: "--into", Description: "folder of the new request (the root by default)"}
+	editNameS      = prompt.Suggest{Text: "--name", Description: "name of the new request"}
+	editHeaderS    = prompt.Suggest{Text: "--header", Description: "header of the new request (\"{key}: {value}\")"}
+	editWithBodyS  = prompt.Suggest{Text: "--body", Description: "write the body of the new request in the $EDITOR"}
+)
+
+type PromptEdit struct {
+	c      *internal.Context
+	logger logger.Logger
+}
+
+func NewPromptEdit(c *internal.Context) internal.PromptAction {
+	p := PromptEdit{c: c}
+	p.logger = c.Log.Namespace(p.GetName())
+	return p
+}
+
+func (p PromptEdit) GetName() string {
+	return "PromptEdit"
+}
+
+func (p PromptEdit) GetPromptExecutor() internal.PromptExecutor {
+	return promptexecutors.NewEditExecutor(*p.c, p.logger)
+}
+
+func (p PromptEdit) GetActionKeys() []string {
+	return []string{"edit", ":c"}
+}
+
+func (p PromptEdit) GetParamKeys() []internal.ParamWithRole {
+	return nil
+}
+
+func (p PromptEdit) GetOptions(markdown bool) []internal.Option {
+	return []internal.Option{
+		{Value: editNewS.Text + " {METHOD} {url}", Description: fmt.Sprintf("%s\n%s", editNewS.Description, prettyprint.FormatTextWithColor(`# :c -new POST {{baseUrl}}/users --into users --name create-user --header 'Content-Type: application/json' --body`, "Y", markdown))},
+		{Value: editIntoS.Text + " {folder}", Description: editIntoS.Description},
+		{Value: editNameS.Text + " {name}", Description: "name of the new request (the last url path segment by default) or of the duplicated one"},
+		{Value: editHeaderS.Text + " {header}", Description: editHeaderS.Description + ", can be repeated"},
+		{Value: editWithBodyS.Text, Description: editWithBodyS.Description},
+		{Value: editBodyS.Text + " {label}", Description: fmt.Sprintf("%s (%s by default)", editBodyS.Description, prettyprint.FormatTextWithColor("vi", "Y", markdown))},
+		{Value: editRenameS.Text + " {label} {name}", Description: editRenameS.Description},
+		{Value: editMoveS.Text + " {label} {folder}", Description: fmt.Sprintf("%s (%s for the root)\n%s", editMoveS.Description, prettyprint.FormatTextWithColor("/", "Y", markdown), prettyprint.FormatTextWithColor("# :c -move GET../users admin/users", "Y", markdown))},
+		{Value: editDuplicateS.Text + " {label}", Description: fmt.Sprintf("%s in the same folder (named \"{name} copy\" by default)", editDuplicateS.Description)},
+		{Value: editDeleteS.Text + " {label}", Description: editDeleteS.Description},
+	}
+}
+
+func (p PromptEdit) GetDescription(markdown bool) string {
+	builder := strings.Builder{}
+	builder.WriteString("Create, edit, duplicate and delete the requests of the current collection (saved after confirmation).")
+	builder.WriteString(fmt.Sprintf("\n%s", prettyprint.FormatTextWithColor("# :c -rename GET../users list-users", "Y", markdown)))
+	return builder.String()
+}
+
+func (p PromptEdit) PromptSuggest(in []string, d prompt.Document) ([]prompt.Suggest, error) {
+	if !slices.Contains(p.GetActionKeys(), in[0]) || p.c.Collection == nil {
+		return []prompt.Suggest{}, nil
+	}
+	commands := []prompt.Suggest{editNewS, editBodyS, editRenameS, editMoveS, editDuplicateS, editDeleteS}
+	if len(in) < 2 || len(in) == 2 && d.GetWordBeforeCursor() != "" {
+		return commands, nil
+	}
+
+	last := in[len(in)-1]
+	if d.GetWordBeforeCursor() != "" && len(in) > 2 {
+		last = in[len(in)-2]
+	}
+	switch {
+	case slices.Contains([]string{editBodyS.Text, editRenameS.Text, editMoveS.Text, editDuplicateS.Text, editDeleteS.Text}, last):
+		return slicesutil.TransformT[postman.Item, prompt.Suggest](p.c.Collection.GetRequests("", ""), func(item postman.Item) (*prompt.Suggest, error) {
+			return &prompt.Suggest{Text: item.GetLabel(), Description: item.Request.Url.Raw}, nil
+		}), nil
+	case last == editIntoS.Text || len(in) > 2 && in[len(in)-2] == editMoveS.Text && in[1] == editMoveS.Text:
+		return slicesutil.TransformT[string, prompt.Suggest](p.c.Collection.GetFolders(), func(folder string) (*prompt.Suggest, error) {
+			return &prompt.Suggest{Text: folder, Description: "folder"}, nil
+		}), nil
+	case last == editNewS.Text:
+		return slicesutil.TransformT[string, prompt.Suggest]([]string{"GET", "POST", "PUT", "PATCH", "DELETE", "HEAD", "OPTIONS"}, func(method string) (*prompt.Suggest, error) {
+			return &prompt.Suggest{Text: method, Description: "method"}, nil
+		}), nil
+	case in[1] == editNewS.Text:
+		return slicesutil.FilterT([]prompt.Suggest{editIntoS, editNameS, editHeaderS, editWithBodyS}, func(s prompt.Suggest) bool {
+			return s.Text == editHeaderS.Text || !slices.Contains(in, s.Text)
+		}), nil
+	case in[1] == editDuplicateS.Text && !slices.Contains(in, editNameS.Text):
+		return []prompt.Suggest{editNameS}, nil
+	}
+	return []prompt.Suggest{}, nil
+}
+
+func (p PromptEdit) PromptExecutor(in []string) *internal.PromptCallback {
+	if internal.HasRightToExecute(p, in, internal.APP_MODE) {
+		if p.c.Collection == nil {
+			p.c.Print("WARN", "select a collection before to edit it")
+			internal.EXIT_CODE = 1
+			return nil
+		}
+		if len(in) < 2 {
+			p.c.Print("WARN", "select a command (%s)", strings.Join([]string{editNewS.Text, editBodyS.Text, editRenameS.Text, editMoveS.Text, editDuplicateS.Text, editDeleteS.Text}, ", "))
+			internal.EXIT_CODE = 1
+			return nil
+		}
+
+		var text string
+		var ok bool
+		collection := *p.c.Collection
+		switch in[1] {
+		case editNewS.Text:
+			text, ok = p.newRequest(in, &collection)
+		case editBodyS.Text:
+			text, ok = p.editBody(in, &collection)
+		case editRenameS.Text:
+			text, ok = p.rename(in, &collection)
+		case editMoveS.Text:
+			text, ok = p.move(in, &collection)
+		case editDuplicateS.Text:
+			text, ok = p.duplicate(in, &collection)
+		case editDeleteS.Text:
+			text, ok = p.delete(in, &collection)
+		default:
+			p.c.Print("WARN", "unknown command {%s}", in[1])
+			internal.EXIT_CODE = 1
+		}
+		if !ok {
+			return nil
+		}
+
+		return internal.NewPromptCallback(
+			fmt.Sprintf("%s (Yes / No)", text),
+			[]internal.PromptSuggestCallback{
+				internal.NewPromptSuggestCallback("Yes", "Save the collection"),
+				internal.NewPromptSuggestCallback("No", "Do nothing")},
+			p, collection, text)
+	}
+	return nil
+}
+
+// newRequest adds the request ({METHOD} {url}) to the {collection}.
+func (p PromptEdit) newRequest(in []string, collection *postman.Collection) (string, bool) {
+	if len(in) < 4 || strings.HasPrefix(in[2], "-") || strings.HasPrefix(in[3], "-") {
+		p.c.Print("WARN", "select the method and the url (:c -new {METHOD} {url})")
+		internal.EXIT_CODE = 1
+		return "", false
+	}
+
+	executor := p.GetPromptExecutor().(promptexecutors.EditExecutor)
+	var body string
+	if slices.Contains(in, editWithBodyS.Text) {
+		content, err := executor.EditText("", "json")
+		if err != nil {
+			p.logger.Error(err, "body cannot be edited")
+			p.c.Print("ERROR", "unable to edit the body: %s", err.Error())
+			internal.EXIT_CODE = 1
+			return "", false
+		}
+		body = content
+	}
+
+	item, err := executor.NewRequest(in[2], in[3], internal.FindAllNextEl(in, editHeaderS.Text), body)
+	if err != nil {
+		p.logger.Error(err, "request cannot be built", "args", in)
+		p.c.Print("ERROR", "unable to build the request: %s", err.Error())
+		internal.EXIT_CODE = 1
+		return "", false
+	}
+	if name := slicesutil.FindNextEl(in, editNameS.Text); name != "" {
+		item.Name = name
+	}
+	if !p.isAvailable(item.GetLabel()) {
+		return "", false
+	}
+
+	folder := slicesutil.FindNextEl(in, editIntoS.Text)
+	collection.AddItem(folder, *item)
+	return fmt.Sprintf("Add the request {%s} to the folder \"%s\"", item.GetLabel(), "/"+strings.Trim(folder, "/")), true
+}
+
+// editBody edits the raw body of the request in the $EDITOR.
+func (p PromptEdit) editBody(in []string, collection *postman.Collection) (string, bool) {
+	item, ok := p.findItem(in)
+	if !ok {
+		return "", false
+	}
+	body := item.Request.Body
+	if body.Mode != "" && body.Mode != postman.BodyModeRaw {
+		p.c.Print("WARN", "only a raw body can be edited (the body of {%s} is %s)", item.GetLabel(), body.Mode)
+		internal.EXIT_CODE = 1
+		return "", false
+	}
+
+	language := "json"
+	if body.Options != nil && body.Options.Raw.Language != "" {
+		language = body.Options.Raw.Language
+	}
+	content, err := p.GetPromptExecutor().(promptexecutors.EditExecutor).EditText(body.Raw, language)
+	if err != nil {
+		p.logger.Error(err, "body cannot be edited", "label", item.GetLabel())
+		p.c.Print("ERROR", "unable to edit the body: %s", err.Error())
+		internal.EXIT_CODE = 1
+		return "", false
+	}
+	if content == body.Raw {
+		p.c.Print("INFO", "body of {%s} is unchanged", item.GetLabel())
+		return "", false
+	}
+
+	collection.UpdateItem(item.GetLabel(), func(i *postman.Item) {
+		if i.Request.Body.Mode == "" {
+			i.Request.Body.Mode = postman.BodyModeRaw
+			i.Request.Body.Options = &postman.BodyOptions{Raw: postman.BodyRawOptions{Language: language}}
+		}
+		i.Request.Body.Raw = content
+	})
+	return fmt.Sprintf("Update the body of {%s}", item.GetLabel()), true
+}
+
+// rename renames the request.
+func (p PromptEdit) rename(in []string, collection *postman.Collection) (string, bool) {
+	item, ok := p.findItem(in)
+	if !ok {
+		return "", false
+	}
+	if len(in) < 4 {
+		p.c.Print("WARN", "select the new name (:c -rename {label} {name})")
+		internal.EXIT_CODE = 1
+		return "", false
+	}
+
+	label := item.GetLabel()
+	item.Name = in[3]
+	if !p.isAvailable(item.GetLabel()) {
+		return "", false
+	}
+	collection.UpdateItem(label, func(i *postman.Item) {
+		i.Name = item.Name
+	})
+	return fmt.Sprintf("Rename the request {%s} to {%s}", label, item.GetLabel()), true
+}
+
+// move moves the request to another folder (created if it does not exist).
+func (p PromptEdit) move(in []string, collection *postman.Collection) (string, bool) {
+	item, ok := p.findItem(in)
+	if !ok {
+		return "", false
+	}
+	if len(in) < 4 {
+		p.c.Print("WARN", "select the folder (:c -move {label} {folder})")
+		internal.EXIT_CODE = 1
+		return "", false
+	}
+
+	folder := strings.Trim(in[3], "/")
+	collection.RemoveItem(item.GetLabel())
+	collection.AddItem(folder, *item)
+	return fmt.Sprintf("Move the request {%s} to the folder \"/%s\"", item.GetLabel(), folder), true
+}
+
+// duplicate inserts a copy of the request after it.
+func (p PromptEdit) duplicate(in []string, collection *postman.Collection) (string, bool) {
+	item, ok := p.findItem(in)
+	if !ok {
+		return "", false
+	}
+
+	label := item.GetLabel()
+	if name := slicesutil.FindNextEl(in, editNameS.Text); name != "" {
+		item.Name = name
+	} else {
+		item.Name += " copy"
+	}
+	if !p.isAvailable(item.GetLabel()) {
+		return "", false
+	}
+	collection.DuplicateItem(label, item.Name)
+	return fmt.Sprintf("Duplicate the request {%s} as {%s}", label, item.GetLabel()), true
+}
+
+// delete removes the request.
+func (p PromptEdit) delete(in []string, collection *postman.Collection) (string, bool) {
+	item, ok := p.findItem(in)
+	if !ok {
+		return "", false
+	}
+	collection.RemoveItem(item.GetLabel())
+	return fmt.Sprintf("Delete the request {%s}", item.GetLabel()), true
+}
+
+// findItem finds the request of the {label} which follows the command.
+func (p PromptEdit) findItem(in []string) (*postman.Item, bool) {
+	if len(in) < 3 {
+		p.c.Print("WARN", "select a request (:c %s {label})", in[1])
+		internal.EXIT_CODE = 1
+		return nil, false
+	}
+	item, _ := p.c.Collection.GetItem(in[2])
+	if item == nil {
+		p.c.Print("WARN", "request {%s} does not exist in the collection", in[2])
+		internal.EXIT_CODE = 1
+		return nil, false
+	}
+	return item, true
+}
+
+// isAvailable returns {true} if the {label} is not already used by a request of the collection.
+func (p PromptEdit) isAvailable(label string) bool {
+	if p.c.Collection.FindItemByLabel(label) != nil {
+		p.c.Print("WARN", "request {%s} already exists in the collection, choose another name", label)
+		internal.EXIT_CODE = 1
+		return false
+	}
+	return true
+}
+
+func (p PromptEdit) PromptCallback(in []string, actions []internal.PromptAction, args ...any) {
+	if slicesutil.Exist(in, "Yes") {
+		collection := args[0].(postman.Collection)
+		if !p.GetPromptExecutor().(promptexecutors.EditExecutor).SaveCollection(collection) {
+			p.c.Print("ERROR", "unable to write the collection \"%s/%s\"", p.c.WorkspaceName, p.c.CollectionName)
+			internal.EXIT_CODE = 1
+			return
+		}
+		p.c.Collection = &collection
+		p.c.Print("INFO", "%s: done", args[1].(string))
+	}
+}
@@ -1,6 +1,7 @@
 package promptactions
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"slices"
@@ -9,6 +10,7 @@ import (
 
 	"github.com/c-bata/go-prompt"
 	"github.com/joakim-ribier/gcli-4postman/internal"
+	"github.com/joakim-ribier/gcli-4postman/internal/pkg/httputil"
 	"github.com/joakim-ribier/gcli-4postman/internal/pkg/prettyprint"
 	"github.com/joakim-ribier/gcli-4postman/internal/postman"
 	"github.com/joakim-ribier/gcli-4postman/internal/promptexecutors"
@@ -21,9 +23,12 @@ import (
 )
 
 var (
-	httpMethodS = prompt.Suggest{Text: "-m", Description: "filter requests by method (GET, POST...)"}
-	httpUrlS    = prompt.Suggest{Text: "-u", Description: "find a request to execute"}
-	historyS    = prompt.Suggest{Text: "-history", Description: "find a previous request"}
+	httpMethodS   = prompt.Suggest{Text: "-m", Description: "filter requests by method (GET, POST...)"}
+	httpUrlS      = prompt.Suggest{Text: "-u", Description: "find a request to execute"}
+	historyS      = prompt.Suggest{Text: "-history", Description: "find a previous request (list the history if not provided)"}
+	cookiesS      = prompt.Suggest{Text: "-cookies", Description: "list the cookies of the workspace environment"}
+	enableHeaderS = prompt.Suggest{Text: "--enable-header", Description: "send a disabled header"}
+	saveAsS       = prompt.Suggest{Text: "--save-as", Description: "add the executed request with its params values to the collection"}
 )
 
 type PromptExecuteRequest struct {
@@ -67,10 +72,31 @@ func (p PromptExecuteRequest) GetOptions(markdown bool) []internal.Option {
 		{Value: httpUrlS.Text, Description: httpUrlS.Description},
 		{Value: historyS.Text, Description: fmt.Sprintf("%s\n%s", historyS.Description, prettyprint.FormatTextWithColor("# :h -history GET../users/findByName#1 --pretty", "Y", markdown))},
 		{Value: "--search {pattern}", Description: fmt.Sprintf("find data in the response using %s awesome lib\nmore details on %s", prettyprint.FormatTextWithColor("tidwall/gjson", "Y", markdown), prettyprint.FormatTextWithColor("https://github.com/tidwall/gjson", "B", markdown))},
+		{Value: "--search header:{key}", Description: "find a response header"},
+		{Value: "--set {key}={pattern}", Description: fmt.Sprintf("set the env variable {key} with the data found in the response (same pattern as %s)\n%s", prettyprint.FormatTextWithColor("--search", "Y", markdown), prettyprint.FormatTextWithColor("# :h -u POST../login --set token=access_token --persist", "Y", markdown))},
+		{Value: "--persist", Description: "save the env variables updated by --set on the disk"},
 		{Value: "--pretty", Description: "display a beautiful HTTP json response"},
+		{Value: "--headers", Description: "display the request headers sent and the response headers"},
 		{Value: "--full", Description: fmt.Sprintf("display the full response (not limited to %s characters)", prettyprint.FormatTextWithColor(strconv.Itoa(internal.HTTP_BODY_SIZE_LIMIT), "Y", markdown))},
 		{Value: "--save {/path/file.json}", Description: "save the full body response in a file"},
+		{Value: saveAsS.Text + " {folder}/{name}", Description: fmt.Sprintf("%s (the env variables are kept), also available with %s\n%s", saveAsS.Description, prettyprint.FormatTextWithColor(historyS.Text, "Y", markdown), prettyprint.FormatTextWithColor("# :h -u GET../users/findByName {{name}} joakim --save-as users/find-joakim", "Y", markdown))},
+		{Value: "--data {/path/file.csv|json}", Description: fmt.Sprintf("call the request(s) for each row of the data file, the columns are available as {{column}} variables\n%s", prettyprint.FormatTextWithColor("# :h -u POST../login -u GET../me --data users.csv --iterations 2", "Y", markdown))},
+		{Value: "--iterations {n}", Description: "number of iterations (the number of rows of the data file by default)"},
+		{Value: "--load {n} --concurrency {n}", Description: fmt.Sprintf("call the request n times by n workers and display the throughput, the statuses and the latencies (not historised)\n%s", prettyprint.FormatTextWithColor("# :h -u GET../list-users --load 500 --concurrency 20", "Y", markdown))},
+		{Value: "--load-report {/path/file.json}", Description: "save the load report in a file"},
+		{Value: "--expect {assertion}", Description: fmt.Sprintf("check the response with {subject}{operator}{value}, the subject is status, time, size, body, header:{key} or a %s path\nand the operator =, !=, >, >=, <, <=, ~ (regex), !~ (none to check the existence)\n%s", prettyprint.FormatTextWithColor("tidwall/gjson", "Y", markdown), prettyprint.FormatTextWithColor("# :h -u GET../users --expect status=200 --expect 'data.#>0' --expect header:Content-Type~json", "Y", markdown))},
+		{Value: "--as-curl", Description: fmt.Sprintf("display the curl command of the request (with the env values) instead of calling it\n%s", prettyprint.FormatTextWithColor("# :h -u GET../users {{id}} 42 --as-curl", "Y", markdown))},
+		{Value: "--report {format}:{/path/file}", Description: fmt.Sprintf("write the execution report (request, status, time and tests) in a junit (XML) or html file\n%s", prettyprint.FormatTextWithColor("# :h -u GET../users --expect status=200 --report junit:out.xml --report html:out.html", "Y", markdown))},
+		{Value: enableHeaderS.Text + " {key}", Description: "send a disabled header (for this call only)"},
+		{Value: "--timeout {seconds}", Description: fmt.Sprintf("request timeout (%s seconds by default)", prettyprint.FormatTextWithColor(strconv.Itoa(httputil.DefaultTimeout), "Y", markdown))},
+		{Value: "--no-redirects", Description: "do not follow the redirects"},
+		{Value: "--proxy {url}", Description: "send the request through a proxy"},
+		{Value: "--insecure", Description: fmt.Sprintf("skip the TLS certificate verification %s", prettyprint.FormatTextWithColor("!! NOT RECOMMENDED !!", "R", markdown))},
+		{Value: "--cacert {/path/ca.pem}", Description: "add a custom CA bundle to verify the server certificate"},
+		{Value: "--cert {/path/cert.pem} --key {/path/key.pem}", Description: "client certificate (mTLS)"},
+		{Value: "--no-cookies", Description: "do not send (and store) the cookies of the workspace environment"},
 		{Value: "--reset", Description: "reset the collection history requests"},
+		{Value: cookiesS.Text, Description: fmt.Sprintf("%s\n%s", cookiesS.Description, prettyprint.FormatTextWithColor("# :h -cookies --reset", "Y", markdown))},
 	}
 }
 
@@ -85,8 +111,12 @@ func (p PromptExecuteRequest) PromptSuggest(in []string, d prompt.Document) ([]p
 		}), nil
 	}
 
+	if slices.Contains(in, cookiesS.Text) {
+		return []prompt.Suggest{{Text: "--reset", Description: "remove the cookies of the workspace environment"}}, nil
+	}
+
 	if !slices.Contains(in, httpMethodS.Text) && !slices.Contains(in, httpUrlS.Text) {
-		return []prompt.Suggest{httpMethodS, httpUrlS, historyS}, nil
+		return []prompt.Suggest{httpMethodS, httpUrlS, historyS, cookiesS}, nil
 	}
 
 	if len(in) > 1 {
@@ -134,25 +164,51 @@ func (p PromptExecuteRequest) PromptSuggest(in []string, d prompt.Document) ([]p
 		return []prompt.Suggest{}, nil
 	}
 
-	return slicesutil.TransformT[string, prompt.Suggest](item.GetParams(), func(param string) (*prompt.Suggest, error) {
+	if in[len(in)-1] == enableHeaderS.Text && d.GetWordBeforeCursor() == "" {
+		return slicesutil.TransformT[string, prompt.Suggest](item.Request.Header.GetDisabledKeys(), func(key string) (*prompt.Suggest, error) {
+			return &prompt.Suggest{Text: key, Description: "disabled header"}, nil
+		}), nil
+	}
+
+	suggests := []prompt.Suggest{}
+	if len(item.Request.Header.GetDisabledKeys()) > 0 {
+		suggests = append(suggests, enableHeaderS)
+	}
+
+	return append(suggests, slicesutil.TransformT[string, prompt.Suggest](item.GetParams(), func(param string) (*prompt.Suggest, error) {
 		if !slices.Contains(in, param) {
+			if slices.Contains(item.Request.Url.GetPathVariables(), param) {
+				return &prompt.Suggest{Text: param, Description: "path variable"}, nil
+			}
 			return &prompt.Suggest{Text: param, Description: ""}, nil
 		} else {
 			return nil, errors.New("param already exists")
 		}
-	}), nil
+	})...), nil
 }
 
 func (p PromptExecuteRequest) PromptExecutor(in []string) *internal.PromptCallback {
 	if internal.HasRightToExecute(p, in, internal.APP_MODE) {
 		if p.c.Collection == nil {
 			p.c.Print("WARN", "select a collection from the suggestions")
+			internal.EXIT_CODE = 1
+			return nil
+		}
+		if slices.Contains(in, cookiesS.Text) {
+			if slices.Contains(in, "--reset") {
+				p.GetPromptExecutor().(promptexecutors.ExecuteRequestExecutor).ResetCookies()
+				p.c.Print("INFO", "cookies removed for {%s} env", stringsutil.OrElse(p.c.GetEnvName(), "no-env"))
+			} else {
+				p.GetPromptExecutor().(promptexecutors.ExecuteRequestExecutor).DisplayCookies()
+			}
 			return nil
 		}
 		if slices.Contains(in, historyS.Text) && len(in) > 1 {
 			if slices.Contains(in, "--reset") {
 				p.GetPromptExecutor().(promptexecutors.ExecuteRequestExecutor).ResetHistory()
 				p.c.CollectionHistoryRequests = postman.CollectionHistoryItemsLight{}
+			} else if len(in) == 2 {
+				execs.NewDisplayHistoryExec(prettyprint.Print).Display(p.c.CollectionHistoryRequests)
 			} else {
 				if len(in) > 2 {
 					if historyItemLight := p.c.CollectionHistoryRequests.FindByLabel(in[2]); historyItemLight != nil {
@@ -162,18 +218,31 @@ func (p PromptExecuteRequest) PromptExecutor(in []string) *internal.PromptCallba
 							p.c.Log.Error(err, "data cannot be loaded", "ressource", historyItemPath)
 						} else {
 							execs.NewDisplayBodyResponseExec(p.logger, prettyprint.Print).Display(in, &historyItem)
+							p.GetPromptExecutor().(promptexecutors.ExecuteRequestExecutor).WriteReports(in, postman.CollectionRunResults{postman.NewCollectionRunResult(historyItem)})
+							p.saveAs(in, historyItem)
 						}
 					}
 				}
 			}
+		} else if slices.Contains(in, "--as-curl") {
+			p.asCurl(in)
+		} else if slices.Contains(in, "--load") {
+			p.load(in)
+		} else if slices.Contains(in, "--data") || slices.Contains(in, "--iterations") {
+			p.runIterations(in)
 		} else {
 			value := slicesutil.FindNextEl(in, httpUrlS.Text)
 			if item := p.c.Collection.FindItemByLabel(value); item != nil {
-				if response, err := p.GetPromptExecutor().(promptexecutors.ExecuteRequestExecutor).Call(in, *item); err != nil {
-					p.c.Print("ERROR", stringsutil.NewStringS(err.Error()).ReplaceAll("%7B", "{").ReplaceAll("%7D", "}").S())
+				if response, err := p.GetPromptExecutor().(promptexecutors.ExecuteRequestExecutor).Call(in, *item, nil); err != nil {
+					p.c.Print("ERROR", err.Error())
+					internal.EXIT_CODE = 1
 				} else {
 					// refresh the context
 					p.c.CollectionHistoryRequests = append(p.c.CollectionHistoryRequests, response.ToLight())
+					if response.Env != nil && response.Env.Name != "" {
+						// the env may have been updated by the scripts
+						p.c.UpdateEnv(*response.Env)
+					}
 
 					// transform correctly the tab to the initial cmd
 					cmd := slicesutil.TransformT(in, func(v string) (*string, error) {
@@ -187,16 +256,24 @@ func (p PromptExecuteRequest) PromptExecutor(in []string) *internal.PromptCallba
 
 					p.GetPromptExecutor().(promptexecutors.ExecuteRequestExecutor).HistoriseNewCollectionItem(*response)
 					execs.NewDisplayBodyResponseExec(p.logger, prettyprint.Print).Display(in, response)
+					p.GetPromptExecutor().(promptexecutors.ExecuteRequestExecutor).WriteReports(in, postman.CollectionRunResults{postman.NewCollectionRunResult(*response)})
+
+					if env := p.GetPromptExecutor().(promptexecutors.ExecuteRequestExecutor).SetEnvVariables(in, *response); env != nil {
+						p.c.UpdateEnv(*env)
+					}
 
 					if path := slicesutil.FindNextEl(in, "--save"); path != "" {
 						if err := iosutil.Write(response.Data, path); err != nil {
 							p.c.Log.Error(err, "data cannot be writed")
 							p.c.Print("ERROR", "the body's response cannot be saved...")
+							internal.EXIT_CODE = 1
 						}
 					}
+					p.saveAs(in, *response)
 				}
 			} else {
 				p.c.Print("WARN", "request {%s} does not exist in the collection", value)
+				internal.EXIT_CODE = 1
 				return nil
 			}
 		}
@@ -204,6 +281,88 @@ func (p PromptExecuteRequest) PromptExecutor(in []string) *internal.PromptCallba
 	return nil
 }
 
+// asCurl displays the curl command of the request "-u {label}" instead of calling it.
+func (p PromptExecuteRequest) asCurl(in []string) {
+	value := slicesutil.FindNextEl(in, httpUrlS.Text)
+	item := p.c.Collection.FindItemByLabel(value)
+	if item == nil {
+		p.c.Print("WARN", "request {%s} does not exist in the collection", value)
+		internal.EXIT_CODE = 1
+		return
+	}
+
+	curl, err := p.GetPromptExecutor().(promptexecutors.ExecuteRequestExecutor).ToCurl(in, *item)
+	if err != nil {
+		p.c.Print("ERROR", err.Error())
+		internal.EXIT_CODE = 1
+		return
+	}
+	prettyprint.Print(curl)
+}
+
+// saveAs adds the executed request {item} with its params values to the collection ("--save-as {folder}/{name}").
+func (p PromptExecuteRequest) saveAs(in []string, item postman.CollectionHistoryItem) {
+	path := slicesutil.FindNextEl(in, saveAsS.Text)
+	if path == "" {
+		return
+	}
+	collection, err := p.GetPromptExecutor().(promptexecutors.ExecuteRequestExecutor).SaveAs(path, item)
+	if err != nil {
+		p.c.Print("ERROR", err.Error())
+		internal.EXIT_CODE = 1
+		return
+	}
+	p.c.Collection = collection
+	p.c.Print("INFO", "request saved as {%s} in the collection", strings.Trim(path, "/"))
+}
+
+// load calls the request "-u {label}" in load mode (the responses are not historised).
+func (p PromptExecuteRequest) load(in []string) {
+	value := slicesutil.FindNextEl(in, httpUrlS.Text)
+	item := p.c.Collection.FindItemByLabel(value)
+	if item == nil {
+		p.c.Print("WARN", "request {%s} does not exist in the collection", value)
+		internal.EXIT_CODE = 1
+		return
+	}
+
+	executor := p.GetPromptExecutor().(promptexecutors.ExecuteRequestExecutor)
+	report, err := executor.Load(in, *item)
+	if err != nil {
+		p.c.Print("ERROR", err.Error())
+		internal.EXIT_CODE = 1
+		return
+	}
+	executor.DisplayLoadReport(*report)
+
+	if path := slicesutil.FindNextEl(in, "--load-report"); path != "" {
+		data, _ := json.MarshalIndent(report, "", "  ")
+		if err := iosutil.Write(data, path); err != nil {
+			p.c.Log.Error(err, "load report cannot be written", "resource", path)
+			p.c.Print("ERROR", "the load report cannot be saved...")
+			internal.EXIT_CODE = 1
+		}
+	}
+}
+
+// runIterations runs the requests "-u {label}" (can be repeated) for each iteration of the data file.
+func (p PromptExecuteRequest) runIterations(in []string) {
+	labels := internal.FindAllNextEl(in, httpUrlS.Text)
+	for _, label := range labels {
+		if p.c.Collection.FindItemByLabel(label) == nil {
+			p.c.Print("WARN", "request {%s} does not exist in the collection", label)
+			internal.EXIT_CODE = 1
+			return
+		}
+	}
+	requests := func() postman.Items {
+		return slicesutil.TransformT[string, postman.Item](labels, func(label string) (*postman.Item, error) {
+			return p.c.Collection.FindItemByLabel(label), nil
+		})
+	}
+	refreshContextWithRunResults(p.c, promptexecutors.NewRunCollectionExecutor(*p.c, p.logger, prettyprint.Print).Run(in, requests))
+}
+
 func (p PromptExecuteRequest) PromptCallback(in []string, actions []internal.PromptAction, args ...any) {
 	// -- not used
 }
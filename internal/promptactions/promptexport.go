@@ -0,0 +1,111 @@
+package promptactions
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/c-bata/go-prompt"
+	"github.com/joakim-ribier/gcli-4postman/internal"
+	"github.com/joakim-ribier/gcli-4postman/internal/pkg/prettyprint"
+	"github.com/joakim-ribier/gcli-4postman/internal/promptexecutors"
+	"github.com/joakim-ribier/gcli-4postman/pkg/logger"
+	"github.com/joakim-ribier/go-utils/pkg/slicesutil"
+)
+
+var (
+	exportS = prompt.Suggest{Text: "export", Description: "export the current collection in the Postman v2.1 format"}
+)
+
+type PromptExport struct {
+	c      *internal.Context
+	logger logger.Logger
+}
+
+func NewPromptExport(c *internal.Context) internal.PromptAction {
+	p := PromptExport{c: c}
+	p.logger = c.Log.Namespace(p.GetName())
+	return p
+}
+
+func (p PromptExport) GetName() string {
+	return "PromptExport"
+}
+
+func (p PromptExport) GetPromptExecutor() internal.PromptExecutor {
+	return promptexecutors.NewExportExecutor(*p.c, p.logger)
+}
+
+func (p PromptExport) GetActionKeys() []string {
+	return []string{"export", ":x"}
+}
+
+func (p PromptExport) GetParamKeys() []internal.ParamWithRole {
+	return nil
+}
+
+func (p PromptExport) GetOptions(markdown bool) []internal.Option {
+	return []internal.Option{
+		{Value: exportS.Text + " {/path/collection.json}", Description: fmt.Sprintf("%s (not encrypted), the unknown fields (descriptions, examples...) are kept\n%s", exportS.Description, prettyprint.FormatTextWithColor("# :x export ~/Downloads/my-collection.postman_collection.json", "Y", markdown))},
+	}
+}
+
+func (p PromptExport) GetDescription(markdown bool) string {
+	builder := strings.Builder{}
+	builder.WriteString("Export the current collection to a file which can be re-imported in Postman.")
+	builder.WriteString(fmt.Sprintf("\n%s", prettyprint.FormatTextWithColor("# :x export /path/collection.json", "Y", markdown)))
+	return builder.String()
+}
+
+func (p PromptExport) PromptSuggest(in []string, d prompt.Document) ([]prompt.Suggest, error) {
+	if !slices.Contains(p.GetActionKeys(), in[0]) {
+		return []prompt.Suggest{}, nil
+	}
+	if in[0] != exportS.Text && (len(in) < 2 || len(in) == 2 && d.GetWordBeforeCursor() != "") {
+		return []prompt.Suggest{exportS}, nil
+	}
+	return []prompt.Suggest{}, nil
+}
+
+func (p PromptExport) PromptExecutor(in []string) *internal.PromptCallback {
+	if internal.HasRightToExecute(p, in, internal.APP_MODE) {
+		if p.c.Collection == nil {
+			p.c.Print("WARN", "select a collection before to export it")
+			internal.EXIT_CODE = 1
+			return nil
+		}
+		path := internal.ExpandUserHome(slicesutil.FindNextEl(in, exportS.Text))
+		if path == "" {
+			p.c.Print("WARN", "select the file to write (:x export {/path/collection.json})")
+			internal.EXIT_CODE = 1
+			return nil
+		}
+
+		if _, err := os.Stat(path); err == nil {
+			return internal.NewPromptCallback(
+				fmt.Sprintf("Replace the \"%s\" file (Yes / No)", path),
+				[]internal.PromptSuggestCallback{
+					internal.NewPromptSuggestCallback("Yes", "Erase the file"),
+					internal.NewPromptSuggestCallback("No", "Do nothing")},
+				p, path)
+		}
+		p.export(path)
+	}
+	return nil
+}
+
+func (p PromptExport) export(path string) {
+	if !p.GetPromptExecutor().(promptexecutors.ExportExecutor).Export(path) {
+		p.c.Print("ERROR", "unable to export the collection to %s", path)
+		internal.EXIT_CODE = 1
+		return
+	}
+	p.c.Print("INFO", "collection '%s' exported to %s", p.c.Collection.Info.Name, path)
+}
+
+func (p PromptExport) PromptCallback(in []string, actions []internal.PromptAction, args ...any) {
+	if slicesutil.Exist(in, "Yes") {
+		p.export(args[0].(string))
+	}
+}
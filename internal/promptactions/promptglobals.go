@@ -0,0 +1,164 @@
+package promptactions
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/c-bata/go-prompt"
+	"github.com/joakim-ribier/gcli-4postman/internal"
+	"github.com/joakim-ribier/gcli-4postman/internal/pkg/prettyprint"
+	"github.com/joakim-ribier/gcli-4postman/internal/postman"
+	"github.com/joakim-ribier/gcli-4postman/internal/promptexecutors"
+	"github.com/joakim-ribier/gcli-4postman/pkg/logger"
+	"github.com/joakim-ribier/go-utils/pkg/slicesutil"
+)
+
+var (
+	globalsSetS    = prompt.Suggest{Text: "-set", Description: "set global variables"}
+	globalsUnsetS  = prompt.Suggest{Text: "-unset", Description: "remove global variables"}
+	globalsShowS   = prompt.Suggest{Text: "-show", Description: "display the global variables"}
+	globalsHomeS   = prompt.Suggest{Text: "--home", Description: "use the globals shared by all the workspaces (the workspace ones by default)"}
+	globalsRevealS = prompt.Suggest{Text: "--reveal", Description: "display the values (masked by default)"}
+)
+
+type PromptGlobals struct {
+	c      *internal.Context
+	logger logger.Logger
+}
+
+func NewPromptGlobals(c *internal.Context) internal.PromptAction {
+	p := PromptGlobals{c: c}
+	p.logger = c.Log.Namespace(p.GetName())
+	return p
+}
+
+func (p PromptGlobals) GetName() string {
+	return "PromptGlobals"
+}
+
+func (p PromptGlobals) GetPromptExecutor() internal.PromptExecutor {
+	return promptexecutors.NewGlobalsExecutor(*p.c, p.logger)
+}
+
+func (p PromptGlobals) GetActionKeys() []string {
+	return []string{"globals", ":g"}
+}
+
+func (p PromptGlobals) GetParamKeys() []internal.ParamWithRole {
+	return nil
+}
+
+func (p PromptGlobals) GetOptions(markdown bool) []internal.Option {
+	return []internal.Option{
+		{Value: globalsSetS.Text + " {key}={value}", Description: fmt.Sprintf("%s, can be repeated\n%s", globalsSetS.Description, prettyprint.FormatTextWithColor("# :g -set userAgent=gcli-4postman --home", "Y", markdown))},
+		{Value: globalsUnsetS.Text + " {key}", Description: globalsUnsetS.Description + ", can be repeated"},
+		{Value: globalsShowS.Text, Description: globalsShowS.Description},
+		{Value: globalsRevealS.Text, Description: globalsRevealS.Description},
+		{Value: globalsHomeS.Text, Description: globalsHomeS.Description},
+	}
+}
+
+func (p PromptGlobals) GetDescription(markdown bool) string {
+	builder := strings.Builder{}
+	builder.WriteString("Manage the global variables of the workspace (globals.json) or of the home, used after the env and the collection variables.")
+	builder.WriteString(fmt.Sprintf("\n%s", prettyprint.FormatTextWithColor("# :g -set baseUrl=http://localhost:8080", "Y", markdown)))
+	return builder.String()
+}
+
+func (p PromptGlobals) PromptSuggest(in []string, d prompt.Document) ([]prompt.Suggest, error) {
+	if !slices.Contains(p.GetActionKeys(), in[0]) {
+		return []prompt.Suggest{}, nil
+	}
+	if len(in) < 2 || len(in) == 2 && d.GetWordBeforeCursor() != "" {
+		return []prompt.Suggest{globalsSetS, globalsUnsetS, globalsShowS}, nil
+	}
+	options := []prompt.Suggest{globalsHomeS}
+	if in[1] == globalsShowS.Text {
+		options = append(options, globalsRevealS)
+	}
+	if in[1] == globalsUnsetS.Text {
+		globals, _ := p.GetPromptExecutor().(promptexecutors.GlobalsExecutor).Load(slices.Contains(in, globalsHomeS.Text))
+		options = append(options, slicesutil.TransformT[postman.GlobalValue, prompt.Suggest](globals.Values, func(v postman.GlobalValue) (*prompt.Suggest, error) {
+			return &prompt.Suggest{Text: v.Key, Description: "variable"}, nil
+		})...)
+	}
+	return slicesutil.FilterT(options, func(s prompt.Suggest) bool {
+		return !slices.Contains(in, s.Text)
+	}), nil
+}
+
+func (p PromptGlobals) PromptExecutor(in []string) *internal.PromptCallback {
+	if internal.HasRightToExecute(p, in, internal.APP_MODE) {
+		home := slices.Contains(in, globalsHomeS.Text)
+		if !home && p.c.WorkspaceName == "" {
+			p.c.Print("WARN", "load a collection before to manage the workspace globals (or use %s)", globalsHomeS.Text)
+			internal.EXIT_CODE = 1
+			return nil
+		}
+		if len(in) < 2 {
+			p.c.Print("WARN", "select a command (%s, %s, %s)", globalsSetS.Text, globalsUnsetS.Text, globalsShowS.Text)
+			internal.EXIT_CODE = 1
+			return nil
+		}
+
+		executor := p.GetPromptExecutor().(promptexecutors.GlobalsExecutor)
+		globals, err := executor.Load(home)
+		if err != nil {
+			p.c.Print("ERROR", "unable to load the globals '%s'", p.c.GetGlobalsPath(home))
+			internal.EXIT_CODE = 1
+			return nil
+		}
+
+		updated := false
+		switch in[1] {
+		case globalsShowS.Text:
+			executor.Display(globals, home, slices.Contains(in, globalsRevealS.Text))
+		case globalsSetS.Text:
+			for _, value := range p.getArgs(in) {
+				key, v, found := strings.Cut(value, "=")
+				if !found || key == "" {
+					p.c.Print("WARN", "{%s} is not a valid variable, use {key}={value}", value)
+					continue
+				}
+				globals.Set(key, v)
+				updated = true
+			}
+		case globalsUnsetS.Text:
+			for _, key := range p.getArgs(in) {
+				if !globals.Unset(key) {
+					p.c.Print("WARN", "{{%s}} does not exist in the globals", key)
+					continue
+				}
+				updated = true
+			}
+		default:
+			p.c.Print("WARN", "unknown command {%s}", in[1])
+			internal.EXIT_CODE = 1
+		}
+
+		if updated {
+			if !executor.Save(globals, home) {
+				p.c.Print("ERROR", "unable to save the globals '%s'", p.c.GetGlobalsPath(home))
+				internal.EXIT_CODE = 1
+				return nil
+			}
+			if p.c.Collection != nil {
+				p.c.Collection.Globals = executor.GetVariables()
+			}
+			p.c.Print("INFO", "globals '%s' saved", p.c.GetGlobalsPath(home))
+		}
+	}
+	return nil
+}
+
+// getArgs returns the values which follow the command (the options excluded).
+func (p PromptGlobals) getArgs(in []string) []string {
+	return slicesutil.FilterT(in[2:], func(value string) bool {
+		return !strings.HasPrefix(value, "-")
+	})
+}
+
+func (p PromptGlobals) PromptCallback(in []string, actions []internal.PromptAction, args ...any) {
+	// -- not used
+}
@@ -0,0 +1,206 @@
+package promptactions
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/c-bata/go-prompt"
+	"github.com/joakim-ribier/gcli-4postman/internal"
+	"github.com/joakim-ribier/gcli-4postman/internal/pkg/prettyprint"
+	"github.com/joakim-ribier/gcli-4postman/internal/postman"
+	"github.com/joakim-ribier/gcli-4postman/internal/promptexecutors"
+	"github.com/joakim-ribier/gcli-4postman/pkg/logger"
+	"github.com/joakim-ribier/go-utils/pkg/slicesutil"
+)
+
+var (
+	importOpenAPIS   = prompt.Suggest{Text: "openapi", Description: "import an OpenAPI 3.x or Swagger 2.0 file (JSON or YAML)"}
+	importCurlS      = prompt.Suggest{Text: "curl", Description: "import a curl command in the current collection"}
+	importIntoS      = prompt.Suggest{Text: "--into", Description: "folder of the request (the root by default)"}
+	importWorkspaceS = prompt.Suggest{Text: "--workspace", Description: "workspace of the collection (the current one by default)"}
+	importNameS      = prompt.Suggest{Text: "--name", Description: "name of the collection (the API title by default)"}
+)
+
+type PromptImport struct {
+	c      *internal.Context
+	logger logger.Logger
+}
+
+func NewPromptImport(c *internal.Context) internal.PromptAction {
+	p := PromptImport{c: c}
+	p.logger = c.Log.Namespace(p.GetName())
+	return p
+}
+
+func (p PromptImport) GetName() string {
+	return "PromptImport"
+}
+
+func (p PromptImport) GetPromptExecutor() internal.PromptExecutor {
+	return promptexecutors.NewImportExecutor(*p.c, p.logger)
+}
+
+func (p PromptImport) GetActionKeys() []string {
+	return []string{"import", ":i"}
+}
+
+func (p PromptImport) GetParamKeys() []internal.ParamWithRole {
+	return nil
+}
+
+func (p PromptImport) GetOptions(markdown bool) []internal.Option {
+	return []internal.Option{
+		{Value: importOpenAPIS.Text + " {/path/spec.yaml}", Description: fmt.Sprintf("%s: a folder by tag, the example bodies from the schemas and the {{baseUrl}} variable from the servers\n%s", importOpenAPIS.Description, prettyprint.FormatTextWithColor("# :i openapi petstore.yaml --workspace personal --name petstore", "Y", markdown))},
+		{Value: importWorkspaceS.Text + " {workspace}", Description: importWorkspaceS.Description},
+		{Value: importCurlS.Text + " '{curl ...}'", Description: fmt.Sprintf("%s (method, headers, -d, --data-*, -u, -F)\n%s", importCurlS.Description, prettyprint.FormatTextWithColor(`# :i curl 'curl -X POST https://api.github.com/users -H "Accept: application/json" -d "{\"name\": \"joakim\"}"' --into users`, "Y", markdown))},
+		{Value: importIntoS.Text + " {folder}", Description: importIntoS.Description},
+		{Value: importNameS.Text + " {name}", Description: "name of the collection (the API title by default) or of the request (the last url path segment by default)"},
+	}
+}
+
+func (p PromptImport) GetDescription(markdown bool) string {
+	builder := strings.Builder{}
+	builder.WriteString("Import API requests as a local collection.")
+	builder.WriteString(fmt.Sprintf("\n%s", prettyprint.FormatTextWithColor("# :i openapi /path/openapi.json", "Y", markdown)))
+	return builder.String()
+}
+
+func (p PromptImport) PromptSuggest(in []string, d prompt.Document) ([]prompt.Suggest, error) {
+	if !slices.Contains(p.GetActionKeys(), in[0]) {
+		return []prompt.Suggest{}, nil
+	}
+	if len(in) < 2 || len(in) == 2 && d.GetWordBeforeCursor() != "" {
+		return []prompt.Suggest{importOpenAPIS, importCurlS}, nil
+	}
+	if in[1] == importCurlS.Text {
+		if p.c.Collection != nil && in[len(in)-1] == importIntoS.Text && d.GetWordBeforeCursor() == "" {
+			return slicesutil.TransformT[string, prompt.Suggest](p.c.Collection.GetFolders(), func(folder string) (*prompt.Suggest, error) {
+				return &prompt.Suggest{Text: folder, Description: "folder"}, nil
+			}), nil
+		}
+		return slicesutil.FilterT([]prompt.Suggest{importIntoS, importNameS}, func(s prompt.Suggest) bool {
+			return !slices.Contains(in, s.Text)
+		}), nil
+	}
+	if in[len(in)-1] == importWorkspaceS.Text && d.GetWordBeforeCursor() == "" {
+		folders, _ := os.ReadDir(internal.GCLI_4POSTMAN_HOME)
+		return slicesutil.TransformT[os.DirEntry, prompt.Suggest](folders, func(folder os.DirEntry) (*prompt.Suggest, error) {
+			if folder.IsDir() {
+				return &prompt.Suggest{Text: folder.Name(), Description: "workspace"}, nil
+			}
+			return nil, nil
+		}), nil
+	}
+	return slicesutil.FilterT([]prompt.Suggest{importWorkspaceS, importNameS}, func(s prompt.Suggest) bool {
+		return !slices.Contains(in, s.Text)
+	}), nil
+}
+
+func (p PromptImport) PromptExecutor(in []string) *internal.PromptCallback {
+	if internal.HasRightToExecute(p, in, internal.APP_MODE) {
+		if len(in) > 1 && in[1] == importCurlS.Text {
+			p.importCurl(in)
+			return nil
+		}
+
+		path := slicesutil.FindNextEl(in, importOpenAPIS.Text)
+		if path == "" {
+			p.c.Print("WARN", "select a file to import (:i openapi {/path/spec.yaml})")
+			internal.EXIT_CODE = 1
+			return nil
+		}
+
+		workspace := slicesutil.FindNextEl(in, importWorkspaceS.Text)
+		if workspace == "" {
+			workspace = p.c.WorkspaceName
+		}
+		if workspace == "" {
+			p.c.Print("WARN", "load a collection or select a workspace (--workspace {workspace})")
+			internal.EXIT_CODE = 1
+			return nil
+		}
+
+		executor := p.GetPromptExecutor().(promptexecutors.ImportExecutor)
+		collection, err := executor.ParseOpenAPI(path)
+		if err != nil {
+			p.logger.Error(err, "OpenAPI file cannot be imported", "resource", path)
+			p.c.Print("ERROR", "unable to import the OpenAPI file %s: %s", path, err.Error())
+			internal.EXIT_CODE = 1
+			return nil
+		}
+		name := executor.GetCollectionName(*collection, slicesutil.FindNextEl(in, importNameS.Text))
+
+		if _, err := os.Stat(executor.GetCollectionPath(workspace, name)); err == nil {
+			return internal.NewPromptCallback(
+				fmt.Sprintf("Replace the \"%s/%s\" collection (Yes / No)", workspace, name),
+				[]internal.PromptSuggestCallback{
+					internal.NewPromptSuggestCallback("Yes", "Erase the current collection"),
+					internal.NewPromptSuggestCallback("No", "Do nothing")},
+				p, *collection, workspace, name)
+		}
+		p.save(*collection, workspace, name)
+	}
+	return nil
+}
+
+// importCurl appends the request of the curl command to the current collection.
+func (p PromptImport) importCurl(in []string) {
+	if p.c.Collection == nil {
+		p.c.Print("WARN", "select a collection before to import a curl command")
+		internal.EXIT_CODE = 1
+		return
+	}
+
+	// the curl arguments are the ones which are not an option of the action
+	var args []string
+	for i := 2; i < len(in); i++ {
+		if in[i] == importIntoS.Text || in[i] == importNameS.Text {
+			i++
+			continue
+		}
+		args = append(args, in[i])
+	}
+
+	executor := p.GetPromptExecutor().(promptexecutors.ImportExecutor)
+	item, err := executor.ParseCurl(args)
+	if err != nil {
+		p.logger.Error(err, "curl command cannot be parsed", "args", args)
+		p.c.Print("ERROR", "unable to import the curl command: %s", err.Error())
+		internal.EXIT_CODE = 1
+		return
+	}
+	if name := slicesutil.FindNextEl(in, importNameS.Text); name != "" {
+		item.Name = name
+	}
+	if p.c.Collection.FindItemByLabel(item.GetLabel()) != nil {
+		p.c.Print("WARN", "request {%s} already exists in the collection, choose another name (--name {name})", item.GetLabel())
+		internal.EXIT_CODE = 1
+		return
+	}
+
+	collection, ok := executor.AddRequest(slicesutil.FindNextEl(in, importIntoS.Text), *item)
+	if !ok {
+		p.c.Print("ERROR", "unable to write the collection \"%s/%s\"", p.c.WorkspaceName, p.c.CollectionName)
+		internal.EXIT_CODE = 1
+		return
+	}
+	p.c.Collection = collection
+	p.c.Print("INFO", "request {%s} added to the collection", item.GetLabel())
+}
+
+func (p PromptImport) save(collection postman.Collection, workspace, name string) {
+	if !p.GetPromptExecutor().(promptexecutors.ImportExecutor).SaveCollection(collection, workspace, name) {
+		p.c.Print("ERROR", "unable to write the collection \"%s/%s\"", workspace, name)
+		internal.EXIT_CODE = 1
+		return
+	}
+	p.c.Print("INFO", "collection '%s' (%d requests) imported, load it with \":l %s/_%s\"", collection.Info.Name, len(collection.GetRequests("", "")), workspace, name)
+}
+
+func (p PromptImport) PromptCallback(in []string, actions []internal.PromptAction, args ...any) {
+	if slicesutil.Exist(in, "Yes") {
+		p.save(args[0].(postman.Collection), args[1].(string), args[2].(string))
+	}
+}
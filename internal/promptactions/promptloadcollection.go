@@ -10,6 +10,7 @@ import (
 	"github.com/joakim-ribier/gcli-4postman/internal"
 	"github.com/joakim-ribier/gcli-4postman/internal/pkg/prettyprint"
 	"github.com/joakim-ribier/gcli-4postman/internal/postman"
+	"github.com/joakim-ribier/gcli-4postman/internal/promptexecutors"
 	"github.com/joakim-ribier/gcli-4postman/pkg/ioutil"
 	"github.com/joakim-ribier/gcli-4postman/pkg/logger"
 	"github.com/joakim-ribier/go-utils/pkg/slicesutil"
@@ -107,6 +108,7 @@ func (p PromptLoadCollection) PromptExecutor(in []string) *internal.PromptCallba
 			}
 		}
 		p.c.Print("WARN", "select a collection from the suggestions")
+		internal.EXIT_CODE = 1
 	}
 	return nil
 }
@@ -114,6 +116,7 @@ func (p PromptLoadCollection) PromptExecutor(in []string) *internal.PromptCallba
 func (p PromptLoadCollection) load() {
 	if p.c.WorkspaceName == "" || p.c.CollectionName == "" {
 		p.c.Print("WARN", "unable to load collection %s...", p.c.CollectionName)
+		internal.EXIT_CODE = 1
 		return
 	}
 
@@ -121,6 +124,7 @@ func (p PromptLoadCollection) load() {
 	if err != nil {
 		p.logger.Error(err, "file cannot be loaded", "resource", p.c.GetCollectionPath())
 		p.c.Print("ERROR", "unable to load collection '%s/%s'", p.c.WorkspaceName, p.c.CollectionName)
+		internal.EXIT_CODE = 1
 		return
 	} else {
 		p.c.Print("INFO", "loads collection '%s' from workspace '%s'", p.c.CollectionName, p.c.WorkspaceName)
@@ -131,6 +135,7 @@ func (p PromptLoadCollection) load() {
 	if err != nil {
 		p.logger.Error(err, "folder cannot be read", "resource", p.c.GetWorkspacePath())
 		p.c.Print("ERROR", "unable to load environment files in the workspace '%s'", p.c.WorkspaceName)
+		internal.EXIT_CODE = 1
 		p.c.Envs = []postman.Env{}
 	} else {
 		for _, file := range files {
@@ -147,6 +152,11 @@ func (p PromptLoadCollection) load() {
 		}
 	}
 
+	if globals := promptexecutors.NewGlobalsExecutor(*p.c, p.logger).GetVariables(); len(globals) > 0 {
+		p.c.Print("INFO", "loads %d global variables", len(globals))
+		p.c.Collection.Globals = globals
+	}
+
 	files, err = os.ReadDir(p.c.GetCollectionHistoryPathFolder())
 	if err != nil {
 		p.logger.Error(err, "folder cannot be read", "resource", p.c.GetCollectionHistoryPathFolder())
@@ -160,6 +170,7 @@ func (p PromptLoadCollection) load() {
 					p.logger.Error(err, "file cannot be loaded", "resource", p.c.GetCollectionHistoryPathFolder()+"/"+file.Name())
 					p.c.Print("ERROR", "unable to load history request '%s'", file.Name())
 				} else {
+					historyItem.FileName = file.Name()
 					p.c.CollectionHistoryRequests = append(p.c.CollectionHistoryRequests, historyItem)
 				}
 			}
@@ -27,6 +27,7 @@ const (
 	workspacesEndpoint  = "https://api.getpostman.com/workspaces"
 	collectionsEndpoint = "https://api.getpostman.com/collections"
 	envsEndpoint        = "https://api.getpostman.com/environments"
+	globalsEndpoint     = "https://api.getpostman.com/workspaces/%s/global-variables"
 )
 
 type PromptPostman struct {
@@ -95,6 +96,7 @@ func (p PromptPostman) PromptExecutor(in []string) *internal.PromptCallback {
 
 		if apiKey == "" {
 			p.c.Print("WARN", "set {API_KEY} to connect to the account...")
+			internal.EXIT_CODE = 1
 			return nil
 		}
 
@@ -110,7 +112,7 @@ func (p PromptPostman) PromptExecutor(in []string) *internal.PromptCallback {
 		if slicesutil.Exist(in, syncParam) {
 			internal.HistoriseCommand(*p.c, strings.Join(in, " "))
 
-			p.c.Print("INFO", "sync workspace with its collections and environments")
+			p.c.Print("INFO", "sync workspace with its collections, environments and globals")
 			p.c.Print("INFO", "find workspace \"%s\" ...", slicesutil.FindNextEl(in, syncParam))
 			workspace := p.downloadWorkspace(apiKey).Find(slicesutil.FindNextEl(in, syncParam))
 			if workspace == nil {
@@ -129,16 +131,21 @@ func (p PromptPostman) PromptExecutor(in []string) *internal.PromptCallback {
 			collections, _ := p.downloadCollections(apiKey, *workspace)
 			p.c.Print("WARN", "\"%s\" found", slicesutil.ToStringT[postman.Collection](collections, func(c postman.Collection) *string { return &c.Info.Name }, "\", \""))
 
+			p.c.Print("INFO", "download globals ...")
+			globals := p.downloadGlobals(apiKey, *workspace)
+			p.c.Print("INFO", "%d global variables found", len(globals.Values))
+
 			return internal.NewPromptCallback(
 				fmt.Sprintf("Update data for the \"%s\" workspace (Yes / No)", workspace.Name),
 				[]internal.PromptSuggestCallback{
 					internal.NewPromptSuggestCallback("Yes", "Erase current data and reload the collection"),
 					internal.NewPromptSuggestCallback("No", "Do nothing")},
-				p, *workspace, collections, envs)
+				p, *workspace, collections, envs, globals)
 
 		}
 
 		p.c.Print("WARN", "select an available option to continue...")
+		internal.EXIT_CODE = 1
 	}
 	return nil
 }
@@ -205,13 +212,27 @@ func (p PromptPostman) downloadCollections(apiKey string, workspace postman.PSTW
 	return collections, nil
 }
 
-func (p PromptPostman) save(workspace postman.PSTWorkspace, collections []postman.Collection, envs []postman.Env) {
+// downloadGlobals downloads the global variables of the {workspace} (empty if they cannot be downloaded).
+func (p PromptPostman) downloadGlobals(apiKey string, workspace postman.PSTWorkspace) postman.Globals {
+	if bytes := p.call(fmt.Sprintf(globalsEndpoint, workspace.Id), apiKey); bytes != nil {
+		pstGlobals, err := jsonsutil.Unmarshal[postman.PSTGlobals](bytes)
+		if err != nil {
+			p.logger.Error(err, "`bytes` cannot be unmarshaled", "data", bytes)
+		} else {
+			return pstGlobals.ToGlobals()
+		}
+	}
+	return postman.NewGlobals()
+}
+
+func (p PromptPostman) save(workspace postman.PSTWorkspace, collections []postman.Collection, envs []postman.Env, globals postman.Globals) {
 	r := true
 
 	workspaceTemporaryFolder := p.buildWorkspaceRootFolder(workspace.Name, "_"+time.Now().Format("2006-01-02_150405"))
 	if err := os.Mkdir(workspaceTemporaryFolder, os.ModePerm); err != nil {
 		p.logger.Error(err, "folder cannot be created", "resource", workspaceTemporaryFolder)
 		p.c.Print("ERROR", "unable to create temporary workspace folder \"%s\"", workspaceTemporaryFolder)
+		internal.EXIT_CODE = 1
 		return
 	}
 	p.c.Print("INFO", "create temporary folder \"%s\"", workspaceTemporaryFolder)
@@ -230,6 +251,16 @@ func (p PromptPostman) save(workspace postman.PSTWorkspace, collections []postma
 		}
 	}
 
+	if r && len(globals.Values) > 0 {
+		globalsFileName := workspaceTemporaryFolder + "/globals.json"
+		if err := ioutil.Write[postman.Globals](globals, globalsFileName, internal.SECRET); err != nil {
+			p.logger.Error(err, "file cannot be written", "resource", globalsFileName)
+			r = false
+		} else {
+			p.c.Print("INFO", "write file \"globals.json\"")
+		}
+	}
+
 	for _, collection := range collections {
 		collectionName := slug.Make(collection.Info.Name)
 		if collectionName != "" {
@@ -246,6 +277,7 @@ func (p PromptPostman) save(workspace postman.PSTWorkspace, collections []postma
 
 	if !r {
 		p.c.Print("WARN", "unable to terminate the updating, remove the temporary folder... retry!")
+		internal.EXIT_CODE = 1
 		os.RemoveAll(workspaceTemporaryFolder)
 	} else {
 		// remove the current workspace folder if it exists
@@ -253,12 +285,14 @@ func (p PromptPostman) save(workspace postman.PSTWorkspace, collections []postma
 			r = false
 			p.logger.Error(err, "file cannot be deleted", "resource", p.buildWorkspaceRootFolder(workspace.Name, ""))
 			p.c.Print("ERROR", "unable to remove \"%s\" folder", p.buildWorkspaceRootFolder(workspace.Name, ""))
+			internal.EXIT_CODE = 1
 		} else {
 			// rename the temporary workspace to the workspace folder
 			if err := os.Rename(workspaceTemporaryFolder, p.buildWorkspaceRootFolder(workspace.Name, "")); err != nil {
 				r = false
 				p.logger.Error(err, "file cannot be renamed", "from", workspaceTemporaryFolder, "to", p.buildWorkspaceRootFolder(workspace.Name, ""))
 				p.c.Print("ERROR", "unable to rename \"%s\" to \"%s\", do it manually...", workspaceTemporaryFolder, p.buildWorkspaceRootFolder(workspace.Name, ""))
+				internal.EXIT_CODE = 1
 			} else {
 				p.c.Print("INFO", "rename temporary folder to \"%s\"", p.buildWorkspaceRootFolder(workspace.Name, ""))
 			}
@@ -314,7 +348,7 @@ func (p PromptPostman) call(url string, APIKey string) []byte {
 
 func (p PromptPostman) PromptCallback(in []string, actions []internal.PromptAction, args ...any) {
 	if slicesutil.Exist(in, "Yes") {
-		p.save(args[0].(postman.PSTWorkspace), args[1].([]postman.Collection), args[2].([]postman.Env))
+		p.save(args[0].(postman.PSTWorkspace), args[1].([]postman.Collection), args[2].([]postman.Env), args[3].(postman.Globals))
 		p.c.Clean()
 	}
 }
@@ -0,0 +1,130 @@
+package promptactions
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/c-bata/go-prompt"
+	"github.com/joakim-ribier/gcli-4postman/internal"
+	"github.com/joakim-ribier/gcli-4postman/internal/pkg/prettyprint"
+	"github.com/joakim-ribier/gcli-4postman/internal/postman"
+	"github.com/joakim-ribier/gcli-4postman/internal/promptexecutors"
+	"github.com/joakim-ribier/gcli-4postman/pkg/logger"
+	"github.com/joakim-ribier/go-utils/pkg/slicesutil"
+)
+
+var (
+	runFolderS  = prompt.Suggest{Text: "-f", Description: "run the requests of a folder"}
+	runOptionsS = []prompt.Suggest{
+		{Text: "--search", Description: "run the requests which match with a pattern"},
+		{Text: "--delay", Description: "delay (ms) between the requests"},
+		{Text: "--bail", Description: "stop on the first failure"},
+		{Text: "--data", Description: "iteration data file (CSV or JSON)"},
+		{Text: "--iterations", Description: "number of iterations"},
+		{Text: "--expect", Description: "check each response"},
+		{Text: "--report", Description: "junit:{path} or html:{path} report"},
+		{Text: "--no-cookies", Description: "do not send (and store) the cookies"},
+	}
+)
+
+type PromptRunCollection struct {
+	c      *internal.Context
+	logger logger.Logger
+}
+
+func NewPromptRunCollection(c *internal.Context) internal.PromptAction {
+	p := PromptRunCollection{c: c}
+	p.logger = c.Log.Namespace(p.GetName())
+	return p
+}
+
+func (p PromptRunCollection) GetName() string {
+	return "PromptRunCollection"
+}
+
+func (p PromptRunCollection) GetPromptExecutor() internal.PromptExecutor {
+	return promptexecutors.NewRunCollectionExecutor(*p.c, p.logger, prettyprint.Print)
+}
+
+func (p PromptRunCollection) GetActionKeys() []string {
+	return []string{"run", ":r"}
+}
+
+func (p PromptRunCollection) GetParamKeys() []internal.ParamWithRole {
+	return nil
+}
+
+func (p PromptRunCollection) GetOptions(markdown bool) []internal.Option {
+	return []internal.Option{
+		{Value: runFolderS.Text + " {folder}", Description: fmt.Sprintf("run only the requests of the folder\n%s", prettyprint.FormatTextWithColor("# :r -f users/admin", "Y", markdown))},
+		{Value: "--search {pattern}", Description: "run only the requests which match with the pattern"},
+		{Value: "--delay {ms}", Description: "wait between two requests"},
+		{Value: "--bail", Description: "stop the run on the first failure (request error or failed test)"},
+		{Value: "--data {/path/file.csv|json}", Description: fmt.Sprintf("run the requests for each row of the data file (CSV with a header line or JSON array of objects)\nthe columns are available as {{column}} variables\n%s", prettyprint.FormatTextWithColor("# :r -f users --data users.csv", "Y", markdown))},
+		{Value: "--iterations {n}", Description: "number of iterations (the number of rows of the data file by default, the last row is reused if needed)"},
+		{Value: "--expect {assertion}", Description: "check each response (same as the http action)"},
+		{Value: "--report {format}:{/path/file}", Description: fmt.Sprintf("write the run report in a junit (XML) or html file\n%s", prettyprint.FormatTextWithColor("# :r --report junit:out.xml --report html:out.html", "Y", markdown))},
+		{Value: "--no-cookies", Description: "do not send (and store) the cookies of the workspace environment"},
+	}
+}
+
+func (p PromptRunCollection) GetDescription(markdown bool) string {
+	builder := strings.Builder{}
+	builder.WriteString(fmt.Sprintf("Run the requests of the collection in order - %s", prettyprint.FormatTextWithColor("!! BE CAREFUL TO THE ENVIRONMENT !!", "R", markdown)))
+	builder.WriteString("\n_the env variables set by a request (scripts) are used by the next ones_")
+	builder.WriteString(fmt.Sprintf("\n%s", prettyprint.FormatTextWithColor("# :r -f users --delay 500 --bail", "Y", markdown)))
+	return builder.String()
+}
+
+func (p PromptRunCollection) PromptSuggest(in []string, d prompt.Document) ([]prompt.Suggest, error) {
+	if !slices.Contains(p.GetActionKeys(), in[0]) || p.c.Collection == nil {
+		return []prompt.Suggest{}, nil
+	}
+
+	if in[len(in)-1] == runFolderS.Text && d.GetWordBeforeCursor() == "" ||
+		len(in) > 1 && in[len(in)-2] == runFolderS.Text && d.GetWordBeforeCursor() != "" {
+		return slicesutil.TransformT[string, prompt.Suggest](p.c.Collection.GetFolders(), func(folder string) (*prompt.Suggest, error) {
+			return &prompt.Suggest{Text: folder, Description: "folder"}, nil
+		}), nil
+	}
+
+	suggests := slicesutil.FilterT(runOptionsS, func(s prompt.Suggest) bool {
+		return !slices.Contains(in, s.Text) || s.Text == "--expect" || s.Text == "--report"
+	})
+	if !slices.Contains(in, runFolderS.Text) {
+		suggests = append([]prompt.Suggest{runFolderS}, suggests...)
+	}
+	return suggests, nil
+}
+
+func (p PromptRunCollection) PromptExecutor(in []string) *internal.PromptCallback {
+	if internal.HasRightToExecute(p, in, internal.APP_MODE) {
+		if p.c.Collection == nil {
+			p.c.Print("WARN", "select a collection before to run it")
+			internal.EXIT_CODE = 1
+			return nil
+		}
+
+		executor := p.GetPromptExecutor().(promptexecutors.RunCollectionExecutor)
+		refreshContextWithRunResults(p.c, executor.Run(in, func() postman.Items { return executor.FindRequests(in) }))
+	}
+	return nil
+}
+
+func (p PromptRunCollection) PromptCallback(in []string, actions []internal.PromptAction, args ...any) {
+	// -- not used
+}
+
+// refreshContextWithRunResults adds the responses of the run {results} in the history
+// and updates the env with the last one modified.
+func refreshContextWithRunResults(c *internal.Context, results postman.CollectionRunResults) {
+	for _, result := range results {
+		if result.Response != nil {
+			c.CollectionHistoryRequests = append(c.CollectionHistoryRequests, result.Response.ToLight())
+			if result.Response.Env != nil && result.Response.Env.Name != "" {
+				c.UpdateEnv(*result.Response.Env)
+			}
+		}
+	}
+}
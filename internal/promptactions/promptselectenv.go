@@ -9,7 +9,21 @@ import (
 	"github.com/joakim-ribier/gcli-4postman/internal"
 	"github.com/joakim-ribier/gcli-4postman/internal/pkg/prettyprint"
 	"github.com/joakim-ribier/gcli-4postman/internal/postman"
+	"github.com/joakim-ribier/gcli-4postman/internal/promptexecutors"
+	"github.com/joakim-ribier/gcli-4postman/internal/promptexecutors/execs"
 	"github.com/joakim-ribier/gcli-4postman/pkg/logger"
+	"github.com/joakim-ribier/go-utils/pkg/slicesutil"
+)
+
+var (
+	envListS   = prompt.Suggest{Text: "-list", Description: "list the collection environments"}
+	envNewS    = prompt.Suggest{Text: "-new", Description: "create an environment"}
+	envCloneS  = prompt.Suggest{Text: "-clone", Description: "create an environment from another one"}
+	envSetS    = prompt.Suggest{Text: "-set", Description: "set variables of the current environment"}
+	envUnsetS  = prompt.Suggest{Text: "-unset", Description: "remove variables of the current environment"}
+	envShowS   = prompt.Suggest{Text: "-show", Description: "display the variables of an environment (the current one by default)"}
+	envDeleteS = prompt.Suggest{Text: "-delete", Description: "delete an environment (the current one by default)"}
+	envRevealS = prompt.Suggest{Text: "--reveal", Description: "display the values (masked by default)"}
 )
 
 type PromptSelectEnv struct {
@@ -28,7 +42,7 @@ func (p PromptSelectEnv) GetName() string {
 }
 
 func (p PromptSelectEnv) GetPromptExecutor() internal.PromptExecutor {
-	return nil
+	return promptexecutors.NewEnvExecutor(*p.c, p.logger)
 }
 
 func (p PromptSelectEnv) GetActionKeys() []string {
@@ -47,11 +61,46 @@ func (p PromptSelectEnv) GetDescription(markdown bool) string {
 }
 
 func (p PromptSelectEnv) GetOptions(markdown bool) []internal.Option {
-	return []internal.Option{}
+	return []internal.Option{
+		{Value: envListS.Text, Description: envListS.Description},
+		{Value: envNewS.Text + " {name}", Description: envNewS.Description + " and select it"},
+		{Value: envCloneS.Text + " {from} {to}", Description: fmt.Sprintf("%s and select it\n%s", envCloneS.Description, prettyprint.FormatTextWithColor("# :e -clone localhost staging", "Y", markdown))},
+		{Value: envSetS.Text + " {key}={value}", Description: fmt.Sprintf("%s, can be repeated\n%s", envSetS.Description, prettyprint.FormatTextWithColor("# :e -set baseUrl=http://localhost:8080 token=abcd", "Y", markdown))},
+		{Value: envUnsetS.Text + " {key}", Description: envUnsetS.Description + ", can be repeated"},
+		{Value: envShowS.Text + " {name}", Description: envShowS.Description},
+		{Value: envRevealS.Text, Description: envRevealS.Description},
+		{Value: envDeleteS.Text + " {name}", Description: envDeleteS.Description},
+	}
 }
 
 func (p PromptSelectEnv) PromptExecutor(in []string) *internal.PromptCallback {
 	if internal.HasRightToExecute(p, in, internal.APP_MODE) {
+		if slices.Contains(in, envListS.Text) {
+			execs.NewDisplayEnvsExec(prettyprint.Print).Display(p.c.Envs, p.c.GetEnvName())
+			return nil
+		}
+		if len(in) > 1 && slices.Contains([]string{envNewS.Text, envCloneS.Text, envSetS.Text, envUnsetS.Text, envShowS.Text, envDeleteS.Text}, in[1]) {
+			if p.c.WorkspaceName == "" {
+				p.c.Print("WARN", "load a collection before to manage its environments")
+				internal.EXIT_CODE = 1
+				return nil
+			}
+			switch in[1] {
+			case envNewS.Text:
+				p.create(in)
+			case envCloneS.Text:
+				p.clone(in)
+			case envSetS.Text:
+				p.set(in)
+			case envUnsetS.Text:
+				p.unset(in)
+			case envShowS.Text:
+				p.show(in)
+			case envDeleteS.Text:
+				return p.delete(in)
+			}
+			return nil
+		}
 		if len(in) > 1 {
 			selectedEnv := postman.NewEnv()
 			for _, env := range p.c.Envs {
@@ -67,23 +116,232 @@ func (p PromptSelectEnv) PromptExecutor(in []string) *internal.PromptCallback {
 			}
 		}
 		p.c.Print("WARN", "select an environnment from the suggestions")
+		internal.EXIT_CODE = 1
 	}
 	return nil
 }
 
+// create creates the "-new {name}" env and selects it.
+func (p PromptSelectEnv) create(in []string) {
+	name := slicesutil.FindNextEl(in, envNewS.Text)
+	if name == "" {
+		p.c.Print("WARN", "select the name of the environment (:e -new {name})")
+		internal.EXIT_CODE = 1
+		return
+	}
+	env := postman.NewEnv()
+	env.Name = name
+	p.add(env)
+}
+
+// clone creates the "-clone {from} {to}" env with the variables of the {from} one and selects it.
+func (p PromptSelectEnv) clone(in []string) {
+	if len(in) < 4 {
+		p.c.Print("WARN", "select the environment to clone and the new name (:e -clone {from} {to})")
+		internal.EXIT_CODE = 1
+		return
+	}
+	from := p.findEnv(in[2])
+	if from == nil {
+		p.c.Print("WARN", "env {%s} does not exist", in[2])
+		internal.EXIT_CODE = 1
+		return
+	}
+	env := postman.Env{Name: in[3], Params: slices.Clone(from.Params)}
+	p.add(env)
+}
+
+// add writes the new {env} and selects it.
+func (p PromptSelectEnv) add(env postman.Env) {
+	if env.GetName() == "" || env.GetName() == "none" {
+		p.c.Print("WARN", "{%s} is not a valid environment name", env.Name)
+		internal.EXIT_CODE = 1
+		return
+	}
+	if p.findEnv(env.GetName()) != nil {
+		p.c.Print("WARN", "env {%s} already exists", env.GetName())
+		internal.EXIT_CODE = 1
+		return
+	}
+	if !p.GetPromptExecutor().(promptexecutors.EnvExecutor).Save(env) {
+		p.c.Print("ERROR", "unable to save the {%s} env", env.GetName())
+		internal.EXIT_CODE = 1
+		return
+	}
+	p.c.Envs = append(p.c.Envs, env)
+	p.c.Env = &env
+	p.c.Print("INFO", "env {%s} created, switch on it", env.GetName())
+}
+
+// set sets the "-set {key}={value}" variables of the current env.
+func (p PromptSelectEnv) set(in []string) {
+	env, ok := p.getCurrentEnv()
+	if !ok {
+		return
+	}
+	updated := false
+	for _, value := range p.getArgs(in) {
+		key, v, found := strings.Cut(value, "=")
+		if !found || key == "" {
+			p.c.Print("WARN", "{%s} is not a valid variable, use {key}={value}", value)
+			continue
+		}
+		env.Set(key, v)
+		updated = true
+	}
+	if updated {
+		p.save(env)
+	}
+}
+
+// unset removes the "-unset {key}" variables of the current env.
+func (p PromptSelectEnv) unset(in []string) {
+	env, ok := p.getCurrentEnv()
+	if !ok {
+		return
+	}
+	updated := false
+	for _, key := range p.getArgs(in) {
+		if !slices.ContainsFunc(env.Params, func(param postman.EnvParam) bool { return param.Key == key }) {
+			p.c.Print("WARN", "{{%s}} does not exist on {%s} env", key, env.GetName())
+			continue
+		}
+		env.Unset(key)
+		updated = true
+	}
+	if updated {
+		p.save(env)
+	}
+}
+
+// save writes the updated current {env}.
+func (p PromptSelectEnv) save(env postman.Env) {
+	if !p.GetPromptExecutor().(promptexecutors.EnvExecutor).Save(env) {
+		p.c.Print("ERROR", "unable to save the {%s} env", env.GetName())
+		internal.EXIT_CODE = 1
+		return
+	}
+	p.c.UpdateEnv(env)
+	p.c.Print("INFO", "env {%s} saved", env.GetName())
+}
+
+// show displays the variables of the "-show {name}" env (the current one by default).
+func (p PromptSelectEnv) show(in []string) {
+	env, ok := p.getEnvArg(in, envShowS.Text)
+	if !ok {
+		return
+	}
+	p.GetPromptExecutor().(promptexecutors.EnvExecutor).Display(*env, slices.Contains(in, envRevealS.Text))
+}
+
+// delete asks to remove the "-delete {name}" env (the current one by default).
+func (p PromptSelectEnv) delete(in []string) *internal.PromptCallback {
+	env, ok := p.getEnvArg(in, envDeleteS.Text)
+	if !ok {
+		return nil
+	}
+	return internal.NewPromptCallback(
+		fmt.Sprintf("Delete the {%s} env (Yes / No)", env.GetName()),
+		[]internal.PromptSuggestCallback{
+			internal.NewPromptSuggestCallback("Yes", "Remove the env file"),
+			internal.NewPromptSuggestCallback("No", "Do nothing")},
+		p, *env)
+}
+
+// getArgs returns the values which follow the sub-command (the options excluded).
+func (p PromptSelectEnv) getArgs(in []string) []string {
+	return slicesutil.FilterT(in[2:], func(value string) bool {
+		return !strings.HasPrefix(value, "-")
+	})
+}
+
+// getCurrentEnv returns a copy of the current env.
+func (p PromptSelectEnv) getCurrentEnv() (postman.Env, bool) {
+	if p.c.Env == nil || p.c.Env.Name == "" {
+		p.c.Print("WARN", "select an environment before to update its variables")
+		internal.EXIT_CODE = 1
+		return postman.Env{}, false
+	}
+	return *p.c.Env, true
+}
+
+// getEnvArg returns the env which follows the {option} (the current one by default).
+func (p PromptSelectEnv) getEnvArg(in []string, option string) (*postman.Env, bool) {
+	name := slicesutil.FindNextEl(in, option)
+	if name == "" || strings.HasPrefix(name, "-") {
+		env, ok := p.getCurrentEnv()
+		return &env, ok
+	}
+	env := p.findEnv(name)
+	if env == nil {
+		p.c.Print("WARN", "env {%s} does not exist", name)
+		internal.EXIT_CODE = 1
+		return nil, false
+	}
+	return env, true
+}
+
+// findEnv returns the {name} env of the collection.
+func (p PromptSelectEnv) findEnv(name string) *postman.Env {
+	return slicesutil.FindT(p.c.Envs, func(env postman.Env) bool {
+		return env.GetName() == name
+	})
+}
+
 func (p PromptSelectEnv) PromptSuggest(in []string, d prompt.Document) ([]prompt.Suggest, error) {
 	if !slices.Contains(p.GetActionKeys(), in[0]) {
 		return []prompt.Suggest{}, nil
 	}
+	envs := slicesutil.TransformT[postman.Env, prompt.Suggest](p.c.Envs, func(env postman.Env) (*prompt.Suggest, error) {
+		return &prompt.Suggest{Text: env.GetName(), Description: ""}, nil
+	})
+	if len(in) > 2 || len(in) == 2 && d.GetWordBeforeCursor() == "" {
+		switch in[1] {
+		case envShowS.Text:
+			if !slices.Contains(in, envRevealS.Text) {
+				return append([]prompt.Suggest{envRevealS}, envs...), nil
+			}
+			return []prompt.Suggest{}, nil
+		case envCloneS.Text, envDeleteS.Text:
+			if len(in) == 2 || len(in) == 3 && d.GetWordBeforeCursor() != "" {
+				return envs, nil
+			}
+			return []prompt.Suggest{}, nil
+		case envUnsetS.Text:
+			if p.c.Env == nil {
+				return []prompt.Suggest{}, nil
+			}
+			return slicesutil.TransformT[postman.EnvParam, prompt.Suggest](p.c.Env.Params, func(param postman.EnvParam) (*prompt.Suggest, error) {
+				if slices.Contains(in, param.Key) {
+					return nil, nil
+				}
+				return &prompt.Suggest{Text: param.Key, Description: "variable"}, nil
+			}), nil
+		case envNewS.Text, envSetS.Text:
+			return []prompt.Suggest{}, nil
+		}
+	}
 	var suggests = []prompt.Suggest{
 		{Text: "none", Description: "No environment"},
+		envListS, envNewS, envCloneS, envSetS, envUnsetS, envShowS, envDeleteS,
 	}
-	for _, env := range p.c.Envs {
-		suggests = append(suggests, prompt.Suggest{Text: env.GetName(), Description: ""})
-	}
-	return suggests, nil
+	return append(suggests, envs...), nil
 }
 
 func (p PromptSelectEnv) PromptCallback(in []string, actions []internal.PromptAction, args ...any) {
-	// -- not used
+	if slicesutil.Exist(in, "Yes") {
+		env := args[0].(postman.Env)
+		if !p.GetPromptExecutor().(promptexecutors.EnvExecutor).Delete(env) {
+			p.c.Print("ERROR", "unable to delete the {%s} env", env.GetName())
+			internal.EXIT_CODE = 1
+			return
+		}
+		p.c.Envs = slicesutil.FilterT(p.c.Envs, func(e postman.Env) bool {
+			return e.GetName() != env.GetName()
+		})
+		if p.c.GetEnvName() == env.GetName() {
+			p.c.Env = nil
+		}
+		p.c.Print("INFO", "env {%s} deleted", env.GetName())
+	}
 }
@@ -3,6 +3,7 @@ package promptactions
 import (
 	"fmt"
 	"slices"
+	"strconv"
 	"strings"
 
 	"github.com/c-bata/go-prompt"
@@ -10,6 +11,7 @@ import (
 	"github.com/joakim-ribier/gcli-4postman/internal/pkg/prettyprint"
 	"github.com/joakim-ribier/gcli-4postman/internal/promptexecutors"
 	"github.com/joakim-ribier/gcli-4postman/pkg/logger"
+	"github.com/joakim-ribier/go-utils/pkg/genericsutil"
 	"github.com/joakim-ribier/go-utils/pkg/slicesutil"
 )
 
@@ -19,11 +21,23 @@ const (
 	enableOptionParam    = "enable"
 	disableOptionParam   = "disable"
 	secretOptionParam    = "--secret"
+	httpKeyParam         = "-http"
+	noneOptionParam      = "none"
 )
 
+var httpSettingsSuggests = []prompt.Suggest{
+	{Text: "timeout", Description: "default request timeout in seconds"},
+	{Text: "redirects", Description: "follow (or not) the redirects (enable / disable)"},
+	{Text: "proxy", Description: "send the requests through a proxy ({url} or none)"},
+	{Text: "insecure", Description: "skip the TLS certificate verification (enable / disable)"},
+	{Text: "cacert", Description: "custom CA bundle ({/path/ca.pem} or none)"},
+	{Text: "cert", Description: "client certificate for mTLS ({/path/cert.pem} {/path/key.pem} or none)"},
+}
+
 type PromptSettings struct {
 	updateReadmeSuggest prompt.Suggest
 	secureModeSuggest   prompt.Suggest
+	httpSuggest         prompt.Suggest
 
 	c      *internal.Context
 	logger logger.Logger
@@ -33,6 +47,7 @@ func NewPromptSettings(c *internal.Context) internal.PromptAction {
 	p := PromptSettings{
 		updateReadmeSuggest: prompt.Suggest{Text: updateReadmeKeyParam, Description: "update the README from help documentation"},
 		secureModeSuggest:   prompt.Suggest{Text: secureModeKeyParam, Description: "enable or disable the secure mode"},
+		httpSuggest:         prompt.Suggest{Text: httpKeyParam, Description: "display or update the HTTP client settings"},
 		c:                   c,
 	}
 	p.logger = c.Log.Namespace(p.GetName())
@@ -55,6 +70,7 @@ func (p PromptSettings) GetParamKeys() []internal.ParamWithRole {
 	return []internal.ParamWithRole{
 		{Value: updateReadmeKeyParam, Roles: []string{"admin"}},
 		{Value: secureModeKeyParam, Roles: []string{"admin"}},
+		{Value: httpKeyParam, Roles: []string{}},
 	}
 }
 
@@ -70,21 +86,56 @@ func (p PromptSettings) GetOptions(markdown bool) []internal.Option {
 		{Value: p.updateReadmeSuggest.Text, Description: fmt.Sprintf("%s %s", p.updateReadmeSuggest.Description, prettyprint.FormatTextWithColor("// --mode admin", "G", markdown))},
 		{Value: fmt.Sprintf("%s %s", p.secureModeSuggest.Text, enableOptionParam), Description: fmt.Sprintf("enable secure mode by adding (or update) a new secret %s %s", prettyprint.FormatTextWithColor("--secret {secret}", "Y", markdown), prettyprint.FormatTextWithColor("// --mode admin", "G", markdown))},
 		{Value: fmt.Sprintf("%s %s", p.secureModeSuggest.Text, disableOptionParam), Description: fmt.Sprintf("disable secure mode %s %s", prettyprint.FormatTextWithColor("!! NOT RECOMMENDED !!", "R", markdown), prettyprint.FormatTextWithColor("// --mode admin", "G", markdown))},
+		{Value: p.httpSuggest.Text, Description: "display the HTTP client settings"},
+		{Value: fmt.Sprintf("%s timeout {seconds}", p.httpSuggest.Text), Description: "update the default request timeout"},
+		{Value: fmt.Sprintf("%s redirects enable/disable", p.httpSuggest.Text), Description: "follow (or not) the redirects"},
+		{Value: fmt.Sprintf("%s proxy {url}/none", p.httpSuggest.Text), Description: "send the requests through a proxy"},
+		{Value: fmt.Sprintf("%s insecure enable/disable", p.httpSuggest.Text), Description: fmt.Sprintf("skip the TLS certificate verification %s", prettyprint.FormatTextWithColor("!! NOT RECOMMENDED !!", "R", markdown))},
+		{Value: fmt.Sprintf("%s cacert {/path/ca.pem}/none", p.httpSuggest.Text), Description: "custom CA bundle to verify the server certificates"},
+		{Value: fmt.Sprintf("%s cert {/path/cert.pem} {/path/key.pem}/none", p.httpSuggest.Text), Description: "client certificate (mTLS)"},
 	}
 }
 
 func (p PromptSettings) PromptSuggest(in []string, d prompt.Document) ([]prompt.Suggest, error) {
-	if !slices.Contains(p.GetActionKeys(), in[0]) || internal.APP_MODE != "admin" {
+	if !slices.Contains(p.GetActionKeys(), in[0]) {
 		return []prompt.Suggest{}, nil
 	}
 
+	if slices.Contains(in, p.httpSuggest.Text) {
+		return p.getHttpSuggest(in)
+	}
+
+	if internal.APP_MODE != "admin" {
+		return []prompt.Suggest{p.httpSuggest}, nil
+	}
+
 	if slices.Contains(in, p.secureModeSuggest.Text) {
 		return p.getSecureModeSuggest(in)
 	}
 
 	return []prompt.Suggest{
 		p.updateReadmeSuggest,
-		p.secureModeSuggest}, nil
+		p.secureModeSuggest,
+		p.httpSuggest}, nil
+}
+
+func (p PromptSettings) getHttpSuggest(in []string) ([]prompt.Suggest, error) {
+	switch slicesutil.FindNextEl(in, p.httpSuggest.Text) {
+	case "":
+		return httpSettingsSuggests, nil
+	case "redirects", "insecure":
+		if len(in) < 4 {
+			return []prompt.Suggest{
+				{Text: enableOptionParam, Description: ""},
+				{Text: disableOptionParam, Description: ""},
+			}, nil
+		}
+	case "proxy", "cacert", "cert":
+		if len(in) < 4 {
+			return []prompt.Suggest{{Text: noneOptionParam, Description: "remove the setting"}}, nil
+		}
+	}
+	return []prompt.Suggest{}, nil
 }
 
 func (p PromptSettings) getSecureModeSuggest(in []string) ([]prompt.Suggest, error) {
@@ -112,6 +163,10 @@ func (p PromptSettings) PromptExecutor(in []string) *internal.PromptCallback {
 					internal.NewPromptSuggestCallback("No", "...")},
 				p, p.updateReadmeSuggest.Text)
 		}
+		if slicesutil.Exist(in, p.httpSuggest.Text) {
+			p.updateHttpSettings(in)
+			return nil
+		}
 		if slicesutil.Exist(in, p.secureModeSuggest.Text) {
 			if slicesutil.Exist(in, enableOptionParam) {
 				if newSecret := slicesutil.FindNextEl(in, secretOptionParam); newSecret != "" {
@@ -120,6 +175,7 @@ func (p PromptSettings) PromptExecutor(in []string) *internal.PromptCallback {
 					}
 				} else {
 					p.c.Print("WARN", "select a new {secret} to continue...")
+					internal.EXIT_CODE = 1
 				}
 				return nil
 			}
@@ -131,10 +187,65 @@ func (p PromptSettings) PromptExecutor(in []string) *internal.PromptCallback {
 			}
 		}
 		p.c.Print("WARN", "select an available option to continue...")
+		internal.EXIT_CODE = 1
 	}
 	return nil
 }
 
+// updateHttpSettings updates (and persists) the HTTP client settings or displays them if no option is provided.
+func (p PromptSettings) updateHttpSettings(in []string) {
+	executor := p.GetPromptExecutor().(promptexecutors.SettingsExecutor)
+
+	option := slicesutil.FindNextEl(in, p.httpSuggest.Text)
+	if option == "" {
+		executor.DisplayHttpSettings()
+		return
+	}
+
+	value := slicesutil.FindNextEl(in, option)
+	if value == "" {
+		p.c.Print("WARN", "select a value for the {%s} option to continue...", option)
+		internal.EXIT_CODE = 1
+		return
+	}
+
+	settings := p.c.Settings
+	switch option {
+	case "timeout":
+		timeout, err := strconv.Atoi(value)
+		if err != nil || timeout < 0 {
+			p.c.Print("WARN", "{%s} is not a valid timeout (in seconds)", value)
+			internal.EXIT_CODE = 1
+			return
+		}
+		settings.Http.Timeout = timeout
+	case "redirects":
+		settings.Http.NoRedirects = value == disableOptionParam
+	case "proxy":
+		settings.Http.Proxy = genericsutil.When(value, isNone, "", value)
+	case "insecure":
+		settings.Http.Insecure = value == enableOptionParam
+	case "cacert":
+		settings.Http.CACert = genericsutil.When(value, isNone, "", value)
+	case "cert":
+		settings.Http.ClientCert = genericsutil.When(value, isNone, "", value)
+		settings.Http.ClientKey = genericsutil.When(value, isNone, "", slicesutil.FindNextEl(in, value))
+	default:
+		p.c.Print("WARN", "select an available option to continue...")
+		internal.EXIT_CODE = 1
+		return
+	}
+
+	if executor.SaveSettings(settings) {
+		p.c.Settings = settings
+		p.c.Print("INFO", "HTTP settings updated!")
+	}
+}
+
+func isNone(value string) bool {
+	return value == noneOptionParam
+}
+
 func (p PromptSettings) PromptCallback(in []string, actions []internal.PromptAction, args ...any) {
 	if len(args) > 0 && args[0].(string) == p.updateReadmeSuggest.Text {
 		if slicesutil.Exist(in, "Yes") {
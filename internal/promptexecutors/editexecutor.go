@@ -0,0 +1,79 @@
+package promptexecutors
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/joakim-ribier/gcli-4postman/internal"
+	"github.com/joakim-ribier/gcli-4postman/internal/pkg/httputil"
+	"github.com/joakim-ribier/gcli-4postman/internal/postman"
+	"github.com/joakim-ribier/gcli-4postman/pkg/logger"
+)
+
+// defaultEditor is the editor used if the $EDITOR variable is not defined.
+const defaultEditor = "vi"
+
+// Executor for edit action.
+type EditExecutor struct {
+	c      internal.Context
+	logger logger.Logger
+}
+
+// NewEditExecutor builds executor for edit action.
+func NewEditExecutor(c internal.Context, logger logger.Logger) EditExecutor {
+	return EditExecutor{
+		c:      c,
+		logger: logger,
+	}
+}
+
+// NewRequest builds the {method} {url} request with the {headers} ("{key}: {value}") and the {body}
+// (its mode and content type are guessed like a curl command data).
+func (ee EditExecutor) NewRequest(method, url string, headers []string, body string) (*postman.Item, error) {
+	args := []string{"-X", strings.ToUpper(method), url}
+	for _, header := range headers {
+		args = append(args, "-H", header)
+	}
+	if body != "" {
+		args = append(args, "--data-raw", body)
+	}
+	return httputil.ParseCurl(args)
+}
+
+// EditText opens the {content} in the $EDITOR (vi by default) using a temporary file with the {extension}
+// and returns the saved content.
+func (ee EditExecutor) EditText(content, extension string) (string, error) {
+	file, err := os.CreateTemp("", "gcli-4postman-*."+extension)
+	if err != nil {
+		return "", err
+	}
+	defer os.Remove(file.Name())
+	if _, err := file.WriteString(content); err != nil {
+		file.Close()
+		return "", err
+	}
+	file.Close()
+
+	editor := strings.Fields(os.Getenv("EDITOR"))
+	if len(editor) == 0 {
+		editor = []string{defaultEditor}
+	}
+	cmd := exec.Command(editor[0], append(editor[1:], file.Name())...)
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = os.Stdin, os.Stdout, os.Stderr
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("editor %s failed: %w", editor[0], err)
+	}
+
+	data, err := os.ReadFile(file.Name())
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimRight(string(data), "\n"), nil
+}
+
+// SaveCollection writes the {collection} in the current collection file.
+func (ee EditExecutor) SaveCollection(collection postman.Collection) bool {
+	return NewImportExecutor(ee.c, ee.logger).SaveCollection(collection, ee.c.WorkspaceName, ee.c.CollectionName)
+}
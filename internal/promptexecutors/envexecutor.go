@@ -0,0 +1,49 @@
+package promptexecutors
+
+import (
+	"os"
+
+	"github.com/joakim-ribier/gcli-4postman/internal"
+	"github.com/joakim-ribier/gcli-4postman/internal/pkg/prettyprint"
+	"github.com/joakim-ribier/gcli-4postman/internal/postman"
+	"github.com/joakim-ribier/gcli-4postman/internal/promptexecutors/execs"
+	"github.com/joakim-ribier/gcli-4postman/pkg/ioutil"
+	"github.com/joakim-ribier/gcli-4postman/pkg/logger"
+)
+
+// Executor for env action.
+type EnvExecutor struct {
+	c      internal.Context
+	logger logger.Logger
+}
+
+// NewEnvExecutor builds executor for env action.
+func NewEnvExecutor(c internal.Context, logger logger.Logger) EnvExecutor {
+	return EnvExecutor{
+		c:      c,
+		logger: logger,
+	}
+}
+
+// Save writes the {env} in the current workspace (encrypted in secure mode).
+func (ee EnvExecutor) Save(env postman.Env) bool {
+	if err := ioutil.Write[postman.Env](env, ee.c.GetEnvPath(env), internal.SECRET); err != nil {
+		ee.logger.Error(err, "file cannot be written", "resource", ee.c.GetEnvPath(env))
+		return false
+	}
+	return true
+}
+
+// Delete removes the {env} file of the current workspace.
+func (ee EnvExecutor) Delete(env postman.Env) bool {
+	if err := os.Remove(ee.c.GetEnvPath(env)); err != nil {
+		ee.logger.Error(err, "file cannot be removed", "resource", ee.c.GetEnvPath(env))
+		return false
+	}
+	return true
+}
+
+// Display displays the {env} variables (the values are masked if {reveal} is false).
+func (ee EnvExecutor) Display(env postman.Env, reveal bool) {
+	execs.NewDisplayEnvExec(prettyprint.Print).Display(env, reveal)
+}
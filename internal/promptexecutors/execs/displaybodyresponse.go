@@ -3,7 +3,9 @@ package execs
 import (
 	"errors"
 	"fmt"
+	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/joakim-ribier/gcli-4postman/internal"
 	"github.com/joakim-ribier/gcli-4postman/internal/pkg/prettyprint"
@@ -12,9 +14,33 @@ import (
 	"github.com/joakim-ribier/go-utils/pkg/genericsutil"
 	"github.com/joakim-ribier/go-utils/pkg/slicesutil"
 	"github.com/joakim-ribier/go-utils/pkg/stringsutil"
-	"github.com/tidwall/gjson"
 )
 
+// responseDocument is the response structured document ("json" and "ndjson" output formats).
+type responseDocument struct {
+	Number         int            `json:"number"`
+	Label          string         `json:"label"`
+	Method         string         `json:"method"`
+	Url            string         `json:"url"`
+	Status         string         `json:"status"`
+	Proto          string         `json:"proto,omitempty"`
+	TimeInMillis   int64          `json:"timeMs"`
+	Size           int            `json:"size"`
+	ExecutedAt     time.Time      `json:"executedAt"`
+	Env            string         `json:"env,omitempty"`
+	RequestHeaders http.Header    `json:"requestHeaders,omitempty"`
+	RequestBody    any            `json:"requestBody,omitempty"`
+	Headers        http.Header    `json:"headers,omitempty"`
+	Body           any            `json:"body"`
+	Tests          []testDocument `json:"tests,omitempty"`
+}
+
+type testDocument struct {
+	Name   string `json:"name"`
+	Passed bool   `json:"passed"`
+	Error  string `json:"error,omitempty"`
+}
+
 type DisplayBodyResponseExec struct {
 	logger logger.Logger
 	output func(string)
@@ -38,14 +64,29 @@ func (d DisplayBodyResponseExec) Display(in []string, itemResponse *postman.Coll
 
 // DisplayBodyResponse displays body of the response
 func (d DisplayBodyResponseExec) displayBodyResponse(in []string, historyItem postman.CollectionHistoryItem) {
+	if !internal.IsTextOutput() {
+		outputDocument(d.output, newResponseDocument(in, historyItem))
+		return
+	}
 	d.output(fmt.Sprintf(
-		"METHOD=%s STATUS=%s\nURL=%s",
+		"METHOD=%s STATUS=%s PROTO=%s\nURL=%s",
 		prettyprint.FormatTextWithColor(historyItem.Item.Request.Method, "G", false),
 		prettyprint.FormatTextWithColor(historyItem.Status, "G", false),
-		prettyprint.FormatTextWithColor(historyItem.Item.Request.Url.Get(historyItem.Env, historyItem.Params), "G", false),
+		prettyprint.FormatTextWithColor(stringsutil.OrElse(historyItem.Proto, "-"), "G", false),
+		prettyprint.FormatTextWithColor(stringsutil.OrElse(historyItem.Url, historyItem.Item.Request.Url.Get(historyItem.Env, historyItem.Params, historyItem.Scope)), "G", false),
 	))
+	if slicesutil.Exist(in, "--headers") {
+		d.output("REQUEST_HEADERS=")
+		d.displayHeaders(historyItem.RequestHeader)
+		d.output("RESPONSE_HEADERS=")
+		d.displayHeaders(historyItem.Header)
+	}
 	d.output("BODY=")
-	d.output(prettyprint.SPrintJson([]byte(historyItem.Item.Request.Body.Get(historyItem.Env, historyItem.Params)), true))
+	if body := historyItem.Item.Request.Body; body.IsJson() {
+		d.output(prettyprint.SPrintJson([]byte(body.Get(historyItem.Env, historyItem.Params, historyItem.Scope)), true))
+	} else {
+		d.output(body.Get(historyItem.Env, historyItem.Params, historyItem.Scope))
+	}
 	d.output("____")
 	d.output(fmt.Sprintf(
 		"EXECUTED_AT=%s SIZE=%s TIME_(ms)=%s\nRESPONSE=",
@@ -55,7 +96,7 @@ func (d DisplayBodyResponseExec) displayBodyResponse(in []string, historyItem po
 	))
 	if historyItem.GetSize() > 0 {
 		if v := slicesutil.FindNextEl(in, "--search"); v != "" {
-			data := gjson.GetBytes(historyItem.Data, v).String()
+			data := historyItem.Search(v)
 			d.output(prettyprint.SPrintJson(
 				[]byte(stringsutil.OrElse(data, `"no result"`)),
 				slicesutil.Exist(in, "--pretty")))
@@ -76,4 +117,78 @@ func (d DisplayBodyResponseExec) displayBodyResponse(in []string, historyItem po
 			}
 		}
 	}
+	if len(historyItem.TestResults) > 0 {
+		d.displayTestResults(historyItem.TestResults)
+	}
+}
+
+// displayTestResults displays the scripts tests {results}.
+func (d DisplayBodyResponseExec) displayTestResults(results postman.TestResults) {
+	passed := slicesutil.FilterT(results, func(r postman.TestResult) bool { return r.Passed })
+	color := "G"
+	if results.HasFailed() {
+		color = "R"
+	}
+	d.output(fmt.Sprintf("____\nTESTS=%s",
+		prettyprint.FormatTextWithColor(fmt.Sprintf("%d/%d passed", len(passed), len(results)), color, false)))
+	for _, result := range results {
+		if result.Passed {
+			d.output(fmt.Sprintf("%s %s", prettyprint.FormatTextWithColor("[PASS]", "G", false), result.Name))
+		} else {
+			d.output(fmt.Sprintf("%s %s %s", prettyprint.FormatTextWithColor("[FAIL]", "R", false), result.Name, result.Error))
+		}
+	}
+}
+
+// displayHeaders displays the {headers} sorted by key.
+func (d DisplayBodyResponseExec) displayHeaders(headers http.Header) {
+	keys := make([]string, 0, len(headers))
+	for key := range headers {
+		keys = append(keys, key)
+	}
+	for _, key := range slicesutil.Sort(keys) {
+		for _, value := range headers[key] {
+			d.output(fmt.Sprintf("%s: %s", prettyprint.FormatTextWithColor(key, "B", false), value))
+		}
+	}
+}
+
+// newResponseDocument builds the structured document of the response (the body is the "--search" result if provided).
+func newResponseDocument(in []string, historyItem postman.CollectionHistoryItem) responseDocument {
+	body := jsonOrString(historyItem.Data)
+	if v := slicesutil.FindNextEl(in, "--search"); v != "" {
+		body = jsonOrString([]byte(historyItem.Search(v)))
+	}
+	var requestBody any
+	if raw := historyItem.Item.Request.Body.Get(historyItem.Env, historyItem.Params, historyItem.Scope); raw != "" {
+		requestBody = jsonOrString([]byte(raw))
+	}
+	var env string
+	if historyItem.Env != nil {
+		env = historyItem.Env.GetName()
+	}
+
+	return responseDocument{
+		Number:         historyItem.Number,
+		Label:          historyItem.Item.GetLabel(),
+		Method:         historyItem.Item.Request.Method,
+		Url:            stringsutil.OrElse(historyItem.Url, historyItem.Item.Request.Url.Get(historyItem.Env, historyItem.Params, historyItem.Scope)),
+		Status:         historyItem.Status,
+		Proto:          historyItem.Proto,
+		TimeInMillis:   historyItem.TimeInMillis,
+		Size:           historyItem.GetSize(),
+		ExecutedAt:     historyItem.ExecutedAt,
+		Env:            env,
+		RequestHeaders: historyItem.RequestHeader,
+		RequestBody:    requestBody,
+		Headers:        historyItem.Header,
+		Body:           body,
+		Tests:          newTestDocuments(historyItem.TestResults),
+	}
+}
+
+func newTestDocuments(results postman.TestResults) []testDocument {
+	return slicesutil.TransformT[postman.TestResult, testDocument](results, func(r postman.TestResult) (*testDocument, error) {
+		return &testDocument{Name: r.Name, Passed: r.Passed, Error: r.Error}, nil
+	})
 }
@@ -4,10 +4,20 @@ import (
 	"strings"
 
 	"github.com/jedib0t/go-pretty/v6/list"
+	"github.com/joakim-ribier/gcli-4postman/internal"
 	"github.com/joakim-ribier/gcli-4postman/internal/pkg/prettyprint"
 	"github.com/joakim-ribier/gcli-4postman/internal/postman"
 )
 
+// requestDocument is the collection request structured document ("json" and "ndjson" output formats).
+type requestDocument struct {
+	Folder string `json:"folder,omitempty"`
+	Name   string `json:"name"`
+	Label  string `json:"label"`
+	Method string `json:"method"`
+	Url    string `json:"url"`
+}
+
 type DisplayCollectionExec struct {
 	output func(string)
 }
@@ -20,6 +30,10 @@ func NewDisplayCollectionExec(output func(string)) DisplayCollectionExec {
 
 // Display builds and displays collection using the {out} provided function.
 func (u DisplayCollectionExec) Display(collection postman.Collection, filterBy string) {
+	if !internal.IsTextOutput() {
+		outputDocuments(u.output, newRequestDocuments(collection.Items, "", strings.ToLower(strings.TrimSpace(filterBy)), []requestDocument{}))
+		return
+	}
 	u.output("[" + collection.Info.Name + "'s collection]")
 	if len(collection.Items) > 0 {
 		u.output(u.buildWriter(collection.SortByName(), filterBy).Render())
@@ -58,3 +72,26 @@ func appendItem(writer list.Writer, item postman.Item, filterBy string, iContain
 		return writer
 	}
 }
+
+// recursive function that builds the documents of the requests (in the collection order) which match with the {filterBy} pattern.
+func newRequestDocuments(items postman.Items, folder, filterBy string, documents []requestDocument) []requestDocument {
+	for _, item := range items {
+		if item.Items != nil {
+			documents = newRequestDocuments(item.Items, strings.TrimPrefix(folder+"/"+item.Name, "/"), filterBy, documents)
+			continue
+		}
+		if !item.IsRequest() {
+			continue
+		}
+		if filterBy == "" || strings.Contains(strings.ToLower(item.GetLabel()), filterBy) || strings.Contains(strings.ToLower(folder), filterBy) {
+			documents = append(documents, requestDocument{
+				Folder: folder,
+				Name:   item.Name,
+				Label:  item.GetLabel(),
+				Method: item.Request.Method,
+				Url:    item.Request.Url.Raw,
+			})
+		}
+	}
+	return documents
+}
@@ -0,0 +1,45 @@
+package execs
+
+import (
+	"fmt"
+
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/joakim-ribier/gcli-4postman/internal/pkg/httputil"
+)
+
+type DisplayCookiesExec struct {
+	output func(string)
+}
+
+func NewDisplayCookiesExec(output func(string)) DisplayCookiesExec {
+	return DisplayCookiesExec{
+		output: output,
+	}
+}
+
+// Display builds and displays the {cookies} using the {out} provided function.
+func (d DisplayCookiesExec) Display(cookies httputil.Cookies) {
+	if len(cookies) == 0 {
+		d.output("...no cookies...")
+		return
+	}
+
+	t := table.NewWriter()
+	t.SetStyle(table.StyleLight)
+	t.AppendHeader(table.Row{"Domain", "Path", "Name", "Value", "Expires"})
+	for _, cookie := range cookies {
+		expires := "session"
+		if !cookie.Expires.IsZero() {
+			expires = cookie.Expires.Format("2006-01-02 15:04:05")
+		}
+		t.AppendRow(table.Row{cookie.GetDomain(), cookie.Path, cookie.Name, truncate(cookie.Value, 50), expires})
+	}
+	d.output(t.Render())
+}
+
+func truncate(value string, max int) string {
+	if len(value) > max {
+		return fmt.Sprintf("%s...", value[:max])
+	}
+	return value
+}
@@ -0,0 +1,56 @@
+package execs
+
+import (
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/joakim-ribier/gcli-4postman/internal"
+	"github.com/joakim-ribier/gcli-4postman/internal/postman"
+)
+
+// maskedValue replaces the env values which are not revealed.
+const maskedValue = "********"
+
+// envVariablesDocument is the env variables structured document ("json" and "ndjson" output formats).
+type envVariablesDocument struct {
+	Name      string                `json:"name"`
+	Variables []envVariableDocument `json:"variables"`
+}
+
+type envVariableDocument struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+type DisplayEnvExec struct {
+	output func(string)
+}
+
+func NewDisplayEnvExec(output func(string)) DisplayEnvExec {
+	return DisplayEnvExec{
+		output: output,
+	}
+}
+
+// Display displays the {env} variables (the values are masked if {reveal} is false) using the {out} provided function.
+func (d DisplayEnvExec) Display(env postman.Env, reveal bool) {
+	document := envVariablesDocument{Name: env.GetName(), Variables: []envVariableDocument{}}
+	for _, param := range env.Params {
+		value := param.Value
+		if !reveal && value != "" {
+			value = maskedValue
+		}
+		document.Variables = append(document.Variables, envVariableDocument{Key: param.Key, Value: value})
+	}
+	if !internal.IsTextOutput() {
+		outputDocument(d.output, document)
+		return
+	}
+
+	t := table.NewWriter()
+	t.SetStyle(table.StyleLight)
+	t.SetTitle(document.Name)
+	t.AppendHeader(table.Row{"Key", "Value"})
+	for _, variable := range document.Variables {
+		t.AppendRow(table.Row{variable.Key, variable.Value})
+	}
+	d.output(t.Render())
+}
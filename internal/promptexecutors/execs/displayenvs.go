@@ -0,0 +1,49 @@
+package execs
+
+import (
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/joakim-ribier/gcli-4postman/internal"
+	"github.com/joakim-ribier/gcli-4postman/internal/pkg/prettyprint"
+	"github.com/joakim-ribier/gcli-4postman/internal/postman"
+)
+
+// envDocument is the env structured document ("json" and "ndjson" output formats).
+type envDocument struct {
+	Name      string `json:"name"`
+	Current   bool   `json:"current"`
+	Variables int    `json:"variables"`
+}
+
+type DisplayEnvsExec struct {
+	output func(string)
+}
+
+func NewDisplayEnvsExec(output func(string)) DisplayEnvsExec {
+	return DisplayEnvsExec{
+		output: output,
+	}
+}
+
+// Display displays the collection {envs} (the {current} one is highlighted) using the {out} provided function.
+func (d DisplayEnvsExec) Display(envs []postman.Env, current string) {
+	documents := []envDocument{}
+	for _, env := range envs {
+		documents = append(documents, envDocument{Name: env.GetName(), Current: env.GetName() == current, Variables: len(env.Params)})
+	}
+	if !internal.IsTextOutput() {
+		outputDocuments(d.output, documents)
+		return
+	}
+
+	t := table.NewWriter()
+	t.SetStyle(table.StyleLight)
+	t.AppendHeader(table.Row{"Env", "Variables", ""})
+	for _, document := range documents {
+		selected := ""
+		if document.Current {
+			selected = prettyprint.FormatTextWithColor("*", "G", false)
+		}
+		t.AppendRow(table.Row{document.Name, document.Variables, selected})
+	}
+	d.output(t.Render())
+}
@@ -0,0 +1,52 @@
+package execs
+
+import (
+	"time"
+
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/joakim-ribier/gcli-4postman/internal"
+	"github.com/joakim-ribier/gcli-4postman/internal/postman"
+)
+
+// historyDocument is the history request structured document ("json" and "ndjson" output formats).
+type historyDocument struct {
+	Number     int       `json:"number"`
+	Label      string    `json:"label"`
+	Method     string    `json:"method"`
+	Env        string    `json:"env,omitempty"`
+	ExecutedAt time.Time `json:"executedAt"`
+}
+
+type DisplayHistoryExec struct {
+	output func(string)
+}
+
+func NewDisplayHistoryExec(output func(string)) DisplayHistoryExec {
+	return DisplayHistoryExec{
+		output: output,
+	}
+}
+
+// Display displays the history {items} (the most recent first) using the {out} provided function.
+func (d DisplayHistoryExec) Display(items postman.CollectionHistoryItemsLight) {
+	documents := []historyDocument{}
+	for _, item := range items.SortByExecutedAt() {
+		document := historyDocument{Number: item.Number, Label: item.GetSuggestText(), Method: item.Item.Request.Method, ExecutedAt: item.ExecutedAt}
+		if item.Env != nil {
+			document.Env = item.Env.GetName()
+		}
+		documents = append(documents, document)
+	}
+	if !internal.IsTextOutput() {
+		outputDocuments(d.output, documents)
+		return
+	}
+
+	t := table.NewWriter()
+	t.SetStyle(table.StyleLight)
+	t.AppendHeader(table.Row{"#", "Request", "Env", "Executed at"})
+	for _, document := range documents {
+		t.AppendRow(table.Row{document.Number, document.Label, document.Env, document.ExecutedAt.Format("2006-01-02 15:04:05")})
+	}
+	d.output(t.Render())
+}
@@ -0,0 +1,37 @@
+package execs
+
+import (
+	"strconv"
+
+	"github.com/joakim-ribier/gcli-4postman/internal/pkg/httputil"
+	"github.com/joakim-ribier/gcli-4postman/internal/pkg/prettyprint"
+	"github.com/joakim-ribier/go-utils/pkg/genericsutil"
+	"github.com/joakim-ribier/go-utils/pkg/stringsutil"
+)
+
+type DisplayHttpSettingsExec struct {
+	print func(string, string, ...any)
+}
+
+func NewDisplayHttpSettingsExec(print func(string, string, ...any)) DisplayHttpSettingsExec {
+	return DisplayHttpSettingsExec{
+		print: print,
+	}
+}
+
+// Display displays the HTTP client {options}.
+func (d DisplayHttpSettingsExec) Display(options httputil.Options) {
+	format := func(value string) string {
+		return prettyprint.FormatTextWithColor(stringsutil.OrElse(value, "-"), "Y", false)
+	}
+	enabled := func(value bool) string {
+		return format(genericsutil.When(value, func(b bool) bool { return b }, "enable", "disable"))
+	}
+
+	d.print("INFO", "timeout (s) %s", format(strconv.Itoa(genericsutil.When(options.Timeout, func(t int) bool { return t > 0 }, options.Timeout, httputil.DefaultTimeout))))
+	d.print("INFO", "redirects %s", enabled(!options.NoRedirects))
+	d.print("INFO", "proxy %s", format(options.Proxy))
+	d.print("INFO", "insecure %s", enabled(options.Insecure))
+	d.print("INFO", "cacert %s", format(options.CACert))
+	d.print("INFO", "cert %s key %s", format(options.ClientCert), format(options.ClientKey))
+}
@@ -0,0 +1,78 @@
+package execs
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/joakim-ribier/gcli-4postman/internal"
+	"github.com/joakim-ribier/gcli-4postman/internal/pkg/httputil"
+	"github.com/joakim-ribier/gcli-4postman/internal/pkg/prettyprint"
+	"github.com/joakim-ribier/go-utils/pkg/slicesutil"
+)
+
+// histogramWidth is the max width of the histogram bars.
+const histogramWidth = 40
+
+type DisplayLoadReportExec struct {
+	output func(string)
+}
+
+func NewDisplayLoadReportExec(output func(string)) DisplayLoadReportExec {
+	return DisplayLoadReportExec{
+		output: output,
+	}
+}
+
+// Display displays the load test {report} (summary, statuses, latencies and histogram) using the {out} provided function.
+func (d DisplayLoadReportExec) Display(report httputil.LoadReport) {
+	if !internal.IsTextOutput() {
+		outputDocument(d.output, report)
+		return
+	}
+	d.output(fmt.Sprintf(
+		"REQUEST=%s\nURL=%s\nREQUESTS=%s CONCURRENCY=%s DURATION_(ms)=%s THROUGHPUT_(req/s)=%s",
+		prettyprint.FormatTextWithColor(report.Label, "G", false),
+		prettyprint.FormatTextWithColor(report.Url, "G", false),
+		prettyprint.FormatTextWithColor(fmt.Sprint(report.Requests), "G", false),
+		prettyprint.FormatTextWithColor(fmt.Sprint(report.Concurrency), "G", false),
+		prettyprint.FormatTextWithColor(fmt.Sprintf("%.2f", report.DurationMs), "G", false),
+		prettyprint.FormatTextWithColor(fmt.Sprintf("%.2f", report.Throughput), "G", false),
+	))
+
+	statuses := table.NewWriter()
+	statuses.SetStyle(table.StyleLight)
+	statuses.AppendHeader(table.Row{"Status", "Count"})
+	keys := make([]string, 0, len(report.Statuses))
+	for key := range report.Statuses {
+		keys = append(keys, key)
+	}
+	for _, key := range slicesutil.Sort(keys) {
+		statuses.AppendRow(table.Row{key, report.Statuses[key]})
+	}
+	d.output(statuses.Render())
+
+	latency := table.NewWriter()
+	latency.SetStyle(table.StyleLight)
+	latency.AppendHeader(table.Row{"Latency (ms)", "Min", "Mean", "P50", "P90", "P99", "Max"})
+	latency.AppendRow(table.Row{"",
+		fmt.Sprintf("%.2f", report.Latency.Min), fmt.Sprintf("%.2f", report.Latency.Mean),
+		fmt.Sprintf("%.2f", report.Latency.P50), fmt.Sprintf("%.2f", report.Latency.P90),
+		fmt.Sprintf("%.2f", report.Latency.P99), fmt.Sprintf("%.2f", report.Latency.Max)})
+	d.output(latency.Render())
+
+	if len(report.Histogram) > 0 {
+		d.output("HISTOGRAM=")
+		highest := 0
+		for _, bucket := range report.Histogram {
+			highest = max(highest, bucket.Count)
+		}
+		for _, bucket := range report.Histogram {
+			bar := 0
+			if highest > 0 {
+				bar = bucket.Count * histogramWidth / highest
+			}
+			d.output(fmt.Sprintf("<= %10.2f ms | %s %d", bucket.UpperMs, prettyprint.FormatTextWithColor(strings.Repeat("■", bar), "G", false), bucket.Count))
+		}
+	}
+}
@@ -0,0 +1,112 @@
+package execs
+
+import (
+	"fmt"
+
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/joakim-ribier/gcli-4postman/internal"
+	"github.com/joakim-ribier/gcli-4postman/internal/pkg/prettyprint"
+	"github.com/joakim-ribier/gcli-4postman/internal/postman"
+	"github.com/joakim-ribier/go-utils/pkg/slicesutil"
+)
+
+// runResultDocument is the collection run result structured document ("json" and "ndjson" output formats).
+type runResultDocument struct {
+	Iteration    int            `json:"iteration"`
+	Label        string         `json:"label"`
+	Status       string         `json:"status,omitempty"`
+	TimeInMillis int64          `json:"timeMs"`
+	Error        string         `json:"error,omitempty"`
+	Passed       bool           `json:"passed"`
+	Tests        []testDocument `json:"tests,omitempty"`
+}
+
+type DisplayRunSummaryExec struct {
+	output func(string)
+}
+
+func NewDisplayRunSummaryExec(output func(string)) DisplayRunSummaryExec {
+	return DisplayRunSummaryExec{
+		output: output,
+	}
+}
+
+// Display builds and displays the summary table of the collection run {results} using the {out} provided function.
+func (d DisplayRunSummaryExec) Display(results postman.CollectionRunResults) {
+	if len(results) == 0 {
+		return
+	}
+	if !internal.IsTextOutput() {
+		outputDocuments(d.output, slicesutil.TransformT[postman.CollectionRunResult, runResultDocument](results, func(r postman.CollectionRunResult) (*runResultDocument, error) {
+			document := runResultDocument{Iteration: r.Iteration, Label: r.Label, Error: r.Error, Passed: !r.HasFailed()}
+			if r.Response != nil {
+				document.Status = r.Response.Status
+				document.TimeInMillis = r.Response.TimeInMillis
+				document.Tests = newTestDocuments(r.Response.TestResults)
+			}
+			return &document, nil
+		}))
+		return
+	}
+
+	iterations := results.GetIterations()
+
+	t := table.NewWriter()
+	t.SetStyle(table.StyleLight)
+	t.AppendHeader(table.Row{"#", "Iteration", "Request", "Status", "Time (ms)", "Tests", "Result"})
+	if len(iterations) < 2 {
+		t.SetColumnConfigs([]table.ColumnConfig{{Number: 2, Hidden: true}})
+	}
+
+	var totalTime int64
+	for i, result := range results {
+		status, time, tests := "-", "-", "-"
+		if result.Response != nil {
+			status = result.Response.Status
+			time = fmt.Sprint(result.Response.TimeInMillis)
+			totalTime += result.Response.TimeInMillis
+			if len(result.Response.TestResults) > 0 {
+				passed := slicesutil.FilterT(result.Response.TestResults, func(r postman.TestResult) bool { return r.Passed })
+				tests = fmt.Sprintf("%d/%d", len(passed), len(result.Response.TestResults))
+			}
+		} else {
+			status = truncate(result.Error, 50)
+		}
+		t.AppendRow(table.Row{i + 1, result.Iteration, result.Label, status, time, tests, formatResult(result.HasFailed())})
+	}
+
+	failed := slicesutil.FilterT(results, func(r postman.CollectionRunResult) bool { return r.HasFailed() })
+	t.AppendFooter(table.Row{"", "", fmt.Sprintf("%d requests, %d failed", len(results), len(failed)), "", totalTime, "", formatResult(len(failed) > 0)})
+
+	d.output(t.Render())
+
+	if len(iterations) > 1 {
+		d.displayIterations(results, iterations)
+	}
+}
+
+// displayIterations displays the results broken down per iteration.
+func (d DisplayRunSummaryExec) displayIterations(results postman.CollectionRunResults, iterations []int) {
+	t := table.NewWriter()
+	t.SetStyle(table.StyleLight)
+	t.AppendHeader(table.Row{"Iteration", "Requests", "Failed", "Time (ms)", "Result"})
+	for _, iteration := range iterations {
+		iterationResults := results.FindByIteration(iteration)
+		failed := slicesutil.FilterT(iterationResults, func(r postman.CollectionRunResult) bool { return r.HasFailed() })
+		var totalTime int64
+		for _, result := range iterationResults {
+			if result.Response != nil {
+				totalTime += result.Response.TimeInMillis
+			}
+		}
+		t.AppendRow(table.Row{iteration, len(iterationResults), len(failed), totalTime, formatResult(len(failed) > 0)})
+	}
+	d.output(t.Render())
+}
+
+func formatResult(failed bool) string {
+	if failed {
+		return prettyprint.FormatTextWithColor("FAIL", "R", false)
+	}
+	return prettyprint.FormatTextWithColor("PASS", "G", false)
+}
@@ -0,0 +1,43 @@
+package execs
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+
+	"github.com/joakim-ribier/gcli-4postman/internal"
+)
+
+// outputDocument outputs the {document} as an indented JSON ("json" format) or on a single line ("ndjson" format).
+func outputDocument(output func(string), document any) {
+	buffer := &bytes.Buffer{}
+	encoder := json.NewEncoder(buffer)
+	encoder.SetEscapeHTML(false)
+	if internal.OUTPUT_FORMAT != internal.OutputNdJson {
+		encoder.SetIndent("", "  ")
+	}
+	_ = encoder.Encode(document)
+	output(strings.TrimSuffix(buffer.String(), "\n"))
+}
+
+// outputDocuments outputs the {documents} as an indented JSON array ("json" format) or one per line ("ndjson" format).
+func outputDocuments[T any](output func(string), documents []T) {
+	if internal.OUTPUT_FORMAT == internal.OutputNdJson {
+		for _, document := range documents {
+			outputDocument(output, document)
+		}
+		return
+	}
+	if documents == nil {
+		documents = []T{}
+	}
+	outputDocument(output, documents)
+}
+
+// jsonOrString returns the {data} as a raw JSON value if it is valid, as a string otherwise.
+func jsonOrString(data []byte) any {
+	if len(data) > 0 && json.Valid(data) {
+		return json.RawMessage(data)
+	}
+	return string(data)
+}
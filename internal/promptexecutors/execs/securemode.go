@@ -5,6 +5,7 @@ import (
 	"strings"
 
 	"github.com/joakim-ribier/gcli-4postman/internal"
+	"github.com/joakim-ribier/gcli-4postman/internal/pkg/httputil"
 	"github.com/joakim-ribier/gcli-4postman/internal/postman"
 	"github.com/joakim-ribier/gcli-4postman/pkg/ioutil"
 	"github.com/joakim-ribier/gcli-4postman/pkg/logger"
@@ -37,6 +38,7 @@ func (s SecureModeExec) Decrypt() bool {
 func (s SecureModeExec) encryptOrDecrypt(secret string) bool {
 	if err := s.overwrite(secret); err != nil {
 		s.c.Print("WARN", "data could not be overwritten on disk")
+		internal.EXIT_CODE = 1
 		s.clean(false)
 		return false
 	} else {
@@ -91,12 +93,26 @@ func (s SecureModeExec) overwrite(secret string) error {
 	if err != nil {
 		s.logger.Error(err, "folder cannot be read", "resource", internal.GCLI_4POSTMAN_HOME)
 		s.c.Print("ERROR", "unable to access files in $$GCLI_4POSTMAN_HOME directory %s", internal.GCLI_4POSTMAN_HOME)
+		internal.EXIT_CODE = 1
 		return err
 	} else {
 		for _, file := range files {
 			if file.Name() == "cmd.json" {
 				if err := overwriteT[internal.CMDHistories](internal.GetHomeFilePath(file.Name()), s.tmpSuffix, secret, s.logger); err != nil {
 					s.c.Print("ERROR", "unable to overwrite cmd history %s", file.Name())
+					internal.EXIT_CODE = 1
+					return err
+				}
+			} else if internal.GetHomeFilePath(file.Name()) == s.c.GetSettingsPath() {
+				if err := overwriteT[internal.Settings](s.c.GetSettingsPath(), s.tmpSuffix, secret, s.logger); err != nil {
+					s.c.Print("ERROR", "unable to overwrite settings %s", file.Name())
+					internal.EXIT_CODE = 1
+					return err
+				}
+			} else if internal.GetHomeFilePath(file.Name()) == s.c.GetGlobalsPath(true) {
+				if err := overwriteT[postman.Globals](s.c.GetGlobalsPath(true), s.tmpSuffix, secret, s.logger); err != nil {
+					s.c.Print("ERROR", "unable to overwrite globals %s", file.Name())
+					internal.EXIT_CODE = 1
 					return err
 				}
 			} else {
@@ -116,6 +132,7 @@ func (s SecureModeExec) overwriteWorkspace(workspace, secret string) error {
 	if err != nil {
 		s.logger.Error(err, "folder cannot be read", "resource", internal.GetHomeWorkspacePath(workspace))
 		s.c.Print("ERROR", "unable to access files in workspace directory %s", workspace)
+		internal.EXIT_CODE = 1
 		return err
 	} else {
 		for _, file := range files {
@@ -123,18 +140,35 @@ func (s SecureModeExec) overwriteWorkspace(workspace, secret string) error {
 			if strings.Contains(file.Name(), ".collection.json") {
 				if err := overwriteT[postman.Collection](filePath, s.tmpSuffix, secret, s.logger); err != nil {
 					s.c.Print("ERROR", "unable to overwrite collection %s", filePath)
+					internal.EXIT_CODE = 1
 					return err
 				}
 			}
 			if strings.Contains(file.Name(), ".env.json") {
 				if err := overwriteT[postman.Env](filePath, s.tmpSuffix, secret, s.logger); err != nil {
 					s.c.Print("ERROR", "unable to overwrite environment %s", filePath)
+					internal.EXIT_CODE = 1
+					return err
+				}
+			}
+			if file.Name() == "globals.json" {
+				if err := overwriteT[postman.Globals](filePath, s.tmpSuffix, secret, s.logger); err != nil {
+					s.c.Print("ERROR", "unable to overwrite globals %s", filePath)
+					internal.EXIT_CODE = 1
+					return err
+				}
+			}
+			if strings.Contains(file.Name(), ".cookies.json") {
+				if err := overwriteT[httputil.Cookies](filePath, s.tmpSuffix, secret, s.logger); err != nil {
+					s.c.Print("ERROR", "unable to overwrite cookies %s", filePath)
+					internal.EXIT_CODE = 1
 					return err
 				}
 			}
 			if file.IsDir() && strings.Contains(file.Name(), "-history") {
 				if err := os.RemoveAll(filePath); err != nil {
 					s.c.Print("ERROR", "unable to remove collection history %s", filePath)
+					internal.EXIT_CODE = 1
 					return err
 				}
 			}
@@ -0,0 +1,67 @@
+package execs
+
+import (
+	"strings"
+
+	"github.com/joakim-ribier/gcli-4postman/internal"
+	"github.com/joakim-ribier/gcli-4postman/internal/postman"
+	"github.com/joakim-ribier/gcli-4postman/pkg/ioutil"
+	"github.com/joakim-ribier/gcli-4postman/pkg/logger"
+	"github.com/joakim-ribier/go-utils/pkg/slicesutil"
+)
+
+type SetEnvVariablesExec struct {
+	c      internal.Context
+	logger logger.Logger
+}
+
+func NewSetEnvVariablesExec(c internal.Context, logger logger.Logger) SetEnvVariablesExec {
+	return SetEnvVariablesExec{
+		c:      c,
+		logger: logger,
+	}
+}
+
+// Set extracts the values from the response using the "--set {key}={pattern}" options (the pattern as the "--search" one),
+// adds them in a copy of the current env and writes it on the disk if the "--persist" option is provided.
+func (s SetEnvVariablesExec) Set(in []string, historyItem postman.CollectionHistoryItem) *postman.Env {
+	values := internal.FindAllNextEl(in, "--set")
+	if len(values) == 0 {
+		return nil
+	}
+
+	if s.c.Env == nil || s.c.Env.Name == "" {
+		s.c.Print("WARN", "select an environment before to set variables")
+		internal.EXIT_CODE = 1
+		return nil
+	}
+
+	env := *s.c.Env
+	for _, value := range values {
+		key, pattern, found := strings.Cut(value, "=")
+		if !found || key == "" || pattern == "" {
+			s.c.Print("WARN", "{%s} is not a valid variable, use {key}={pattern}", value)
+			internal.EXIT_CODE = 1
+			continue
+		}
+		if data := historyItem.Search(pattern); data != "" {
+			env.Set(key, data)
+			s.c.Print("INFO", "set {{%s}} on {%s} env", key, env.GetName())
+		} else {
+			s.c.Print("WARN", "no result for {%s} pattern, {{%s}} is not set", pattern, key)
+			internal.EXIT_CODE = 1
+		}
+	}
+
+	if slicesutil.Exist(in, "--persist") {
+		if err := ioutil.Write[postman.Env](env, s.c.GetEnvPath(env), internal.SECRET); err != nil {
+			s.logger.Error(err, "file cannot be written", "resource", s.c.GetEnvPath(env))
+			s.c.Print("ERROR", "unable to save the {%s} env", env.GetName())
+			internal.EXIT_CODE = 1
+		} else {
+			s.c.Print("INFO", "env {%s} saved", env.GetName())
+		}
+	}
+
+	return &env
+}
@@ -27,6 +27,7 @@ func (u UpdateReadmeExec) Update(documentation string) {
 	if err != nil {
 		u.logger.Error(err, "file cannot be read", "resource", "../../README.md")
 		u.c.Print("ERROR", err.Error())
+		internal.EXIT_CODE = 1
 		return
 	}
 
@@ -37,6 +38,7 @@ func (u UpdateReadmeExec) Update(documentation string) {
 	if err := os.WriteFile("../../README.md", []byte(repl), 0644); err != nil {
 		u.logger.Error(err, "file cannot be written", "resource", "../../README.md")
 		u.c.Print("ERROR", err.Error())
+		internal.EXIT_CODE = 1
 		return
 	}
 
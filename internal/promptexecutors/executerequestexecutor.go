@@ -1,13 +1,22 @@
 package promptexecutors
 
 import (
+	"errors"
+	"fmt"
 	"os"
+	"strconv"
+	"strings"
 
 	"github.com/joakim-ribier/gcli-4postman/internal"
 	"github.com/joakim-ribier/gcli-4postman/internal/pkg/httputil"
+	"github.com/joakim-ribier/gcli-4postman/internal/pkg/prettyprint"
+	"github.com/joakim-ribier/gcli-4postman/internal/pkg/reportutil"
+	"github.com/joakim-ribier/gcli-4postman/internal/pkg/scriptutil"
 	"github.com/joakim-ribier/gcli-4postman/internal/postman"
+	"github.com/joakim-ribier/gcli-4postman/internal/promptexecutors/execs"
 	"github.com/joakim-ribier/gcli-4postman/pkg/ioutil"
 	"github.com/joakim-ribier/gcli-4postman/pkg/logger"
+	"github.com/joakim-ribier/go-utils/pkg/iosutil"
 	"github.com/joakim-ribier/go-utils/pkg/slicesutil"
 )
 
@@ -25,35 +34,235 @@ func NewExecuteRequestExecutor(c internal.Context, logger logger.Logger) Execute
 	}
 }
 
-// Call calls the API {item} request.
-func (er ExecuteRequestExecutor) Call(in []string, item postman.Item) (*postman.CollectionHistoryItem, error) {
-	var params []postman.Param = slicesutil.TransformT[string, postman.Param](item.GetParams(), func(param string) (*postman.Param, error) {
-		if value := slicesutil.FindNextEl(in, param); value != "" {
-			return &postman.Param{Key: param, Value: value}, nil
-		} else {
-			return nil, nil
-		}
-	})
+// Call calls the API {item} request with the iteration {data} variables (can be nil).
+func (er ExecuteRequestExecutor) Call(in []string, item postman.Item, data postman.IterationData) (*postman.CollectionHistoryItem, error) {
+	item.Request.Header = item.Request.Header.Enable(internal.FindAllNextEl(in, "--enable-header")...)
+
+	params := data.AppendTo(er.getParams(in, item))
+
+	// the scripts work on copies of the env and the collection variables
+	env := postman.NewEnv()
+	if er.c.Env != nil {
+		env = *er.c.Env
+	}
+	var variables postman.Variables
+	if er.c.Collection != nil {
+		variables = er.c.Collection.GetVariables()
+		// the collection variables updated by the scripts are kept in memory (not saved on the disk)
+		defer func() { er.c.Collection.Current = variables }()
+	}
+
+	sandbox := scriptutil.Sandbox{
+		Env:       &env,
+		Variables: &variables,
+		Params:    params,
+		Scope:     item.Scope,
+		Data:      data,
+		Request: scriptutil.Request{
+			Name:   item.GetLabel(),
+			Method: item.Request.Method,
+			Url:    item.Request.Url.Get(er.c.Env, params, item.Scope),
+			Body:   item.Request.Body.Get(er.c.Env, params, item.Scope),
+			Header: item.Request.Header,
+		},
+		Log: func(value string) { er.c.Print("INFO", "%s", value) },
+	}
+
+	testResults := sandbox.Run(postman.EventPreRequest, item.GetScripts(postman.EventPreRequest))
+	item.Request.Header = sandbox.Request.Header
+	item.Scope = sandbox.Scope
+
+	callEnv := er.c.Env
+	if er.c.Env != nil || len(env.Params) > 0 {
+		callEnv = &env
+	}
+
+	options := er.GetHttpOptions(in)
 
-	response, err := httputil.Call(item, er.c.Env, params)
+	var jar *httputil.CookieJar
+	if !slicesutil.Exist(in, "--no-cookies") {
+		jar = httputil.NewCookieJar(er.LoadCookies())
+		options.Jar = jar
+	}
+
+	response, err := httputil.Call(item, callEnv, params, options)
 	if err != nil {
 		er.logger.Error(err, "request cannot be called", "resource", item.GetLabel(), "url", item.Request.Url.Raw)
 		return nil, err
 	}
 
+	if jar != nil {
+		er.SaveCookies(jar.GetCookies())
+	}
+
+	sandbox.Response = &scriptutil.Response{
+		Code:         response.StatusCode,
+		Status:       response.Status,
+		TimeInMillis: response.TimeInMillis,
+		Header:       response.Header,
+		Body:         response.Body,
+	}
+	testResults = append(testResults, sandbox.Run(postman.EventTest, item.GetScripts(postman.EventTest))...)
+
 	var itemResponse = postman.NewCollectionHistoryItem(
 		len(er.c.CollectionHistoryRequests)+1, item,
 		response.Status, response.TimeInMillis,
 		response.Body, response.ContentLength,
-		er.c.Env, params)
+		callEnv, params).
+		WithHttpDetails(response.Url, response.Proto, response.Header, response.RequestHeader)
+	for _, expression := range internal.FindAllNextEl(in, "--expect") {
+		testResults = append(testResults, itemResponse.Assert(expression))
+	}
+	itemResponse.TestResults = testResults
+
+	if testResults.HasFailed() {
+		internal.EXIT_CODE = 1
+	}
 
 	return &itemResponse, nil
 }
 
+// Load calls the API {item} request "--load {n}" times by "--concurrency {n}" workers and returns the report.
+func (er ExecuteRequestExecutor) Load(in []string, item postman.Item) (*httputil.LoadReport, error) {
+	item.Request.Header = item.Request.Header.Enable(internal.FindAllNextEl(in, "--enable-header")...)
+
+	requests, err := strconv.Atoi(slicesutil.FindNextEl(in, "--load"))
+	if err != nil || requests < 1 {
+		return nil, errors.New("--load must be a positive number of requests")
+	}
+	concurrency := 1
+	if v := slicesutil.FindNextEl(in, "--concurrency"); v != "" {
+		if concurrency, err = strconv.Atoi(v); err != nil || concurrency < 1 {
+			return nil, errors.New("--concurrency must be a positive number of workers")
+		}
+	}
+
+	report, err := httputil.Load(item, er.c.Env, er.getParams(in, item), er.GetHttpOptions(in), requests, concurrency)
+	if err != nil {
+		er.logger.Error(err, "load cannot be executed", "resource", item.GetLabel(), "url", item.Request.Url.Raw)
+		return nil, err
+	}
+	return report, nil
+}
+
+// DisplayLoadReport displays the load test {report}.
+func (er ExecuteRequestExecutor) DisplayLoadReport(report httputil.LoadReport) {
+	execs.NewDisplayLoadReportExec(prettyprint.Print).Display(report)
+}
+
+// ToCurl builds the curl command of the API {item} request with the current env and the params (the scripts are not executed).
+func (er ExecuteRequestExecutor) ToCurl(in []string, item postman.Item) (string, error) {
+	item.Request.Header = item.Request.Header.Enable(internal.FindAllNextEl(in, "--enable-header")...)
+	return httputil.ToCurl(item, er.c.Env, er.getParams(in, item))
+}
+
+// getParams finds the {item} params values in the user input {in}.
+func (er ExecuteRequestExecutor) getParams(in []string, item postman.Item) []postman.Param {
+	return slicesutil.TransformT[string, postman.Param](item.GetParams(), func(param string) (*postman.Param, error) {
+		if value := slicesutil.FindNextEl(in, param); value != "" {
+			return &postman.Param{Key: param, Value: value}, nil
+		} else {
+			return nil, nil
+		}
+	})
+}
+
+// SaveAs adds the collection request of the executed {item} with the user params values to the current collection
+// as "{folder}/{name}" ({path}) and writes it on the disk.
+func (er ExecuteRequestExecutor) SaveAs(path string, item postman.CollectionHistoryItem) (*postman.Collection, error) {
+	folder, name := "", strings.Trim(path, "/")
+	if i := strings.LastIndex(name, "/"); i >= 0 {
+		folder, name = name[:i], name[i+1:]
+	}
+	if name == "" {
+		return nil, errors.New("--save-as requires a name (\"{folder}/{name}\")")
+	}
+
+	// the stored request is baked (the executed one has the inherited auth and the headers updated by the call)
+	stored, _ := er.c.Collection.GetItem(item.Item.GetLabel())
+	if stored == nil {
+		return nil, fmt.Errorf("request {%s} does not exist in the collection", item.Item.GetLabel())
+	}
+	request := stored.Bake(item.Params)
+	request.Name = name
+	if er.c.Collection.FindItemByLabel(request.GetLabel()) != nil {
+		return nil, fmt.Errorf("request {%s} already exists in the collection, choose another name", request.GetLabel())
+	}
+	collection, ok := NewImportExecutor(er.c, er.logger).AddRequest(folder, request)
+	if !ok {
+		return nil, fmt.Errorf("unable to write the collection \"%s/%s\"", er.c.WorkspaceName, er.c.CollectionName)
+	}
+	return collection, nil
+}
+
+// GetHttpOptions builds the HTTP client options from the settings overridden by the user input {in}.
+func (er ExecuteRequestExecutor) GetHttpOptions(in []string) httputil.Options {
+	options := er.c.Settings.Http
+	if v, err := strconv.Atoi(slicesutil.FindNextEl(in, "--timeout")); err == nil {
+		options.Timeout = v
+	}
+	if slicesutil.Exist(in, "--no-redirects") {
+		options.NoRedirects = true
+	}
+	if v := slicesutil.FindNextEl(in, "--proxy"); v != "" {
+		options.Proxy = v
+	}
+	if slicesutil.Exist(in, "--insecure") {
+		options.Insecure = true
+	}
+	if v := slicesutil.FindNextEl(in, "--cacert"); v != "" {
+		options.CACert = v
+	}
+	if v := slicesutil.FindNextEl(in, "--cert"); v != "" {
+		options.ClientCert = v
+		options.ClientKey = slicesutil.FindNextEl(in, "--key")
+	}
+	return options
+}
+
+// SetEnvVariables sets the env variables from the response {item} ("--set {key}={pattern}" options).
+func (er ExecuteRequestExecutor) SetEnvVariables(in []string, item postman.CollectionHistoryItem) *postman.Env {
+	return execs.NewSetEnvVariablesExec(er.c, er.logger).Set(in, item)
+}
+
+// LoadCookies loads the cookies of the current workspace and environment.
+func (er ExecuteRequestExecutor) LoadCookies() httputil.Cookies {
+	cookies, err := ioutil.Load[httputil.Cookies](er.c.GetCookiesPath(), internal.SECRET)
+	if err != nil {
+		// assume that the file does not exist yet
+		return httputil.Cookies{}
+	}
+	return cookies
+}
+
+// SaveCookies writes on the disk the cookies of the current workspace and environment.
+func (er ExecuteRequestExecutor) SaveCookies(cookies httputil.Cookies) bool {
+	if err := ioutil.Write[httputil.Cookies](cookies, er.c.GetCookiesPath(), internal.SECRET); err != nil {
+		er.logger.Error(err, "cookies cannot be written", "resource", er.c.GetCookiesPath())
+		return false
+	}
+	return true
+}
+
+// ResetCookies removes the cookies of the current workspace and environment.
+func (er ExecuteRequestExecutor) ResetCookies() {
+	if err := os.Remove(er.c.GetCookiesPath()); err != nil && !os.IsNotExist(err) {
+		er.logger.Error(err, "file cannot be deleted", "resource", er.c.GetCookiesPath())
+		er.c.Print("ERROR", "unable to remove cookies %s", er.c.GetCookiesPath())
+		internal.EXIT_CODE = 1
+	}
+}
+
+// DisplayCookies displays the cookies of the current workspace and environment.
+func (er ExecuteRequestExecutor) DisplayCookies() {
+	execs.NewDisplayCookiesExec(prettyprint.Print).Display(er.LoadCookies().NotExpired())
+}
+
 // ResetHistory resets the history of the current selected collection.
 func (er ExecuteRequestExecutor) ResetHistory() {
 	if err := os.RemoveAll(er.c.GetCollectionHistoryPathFolder()); err != nil {
 		er.c.Print("ERROR", "unable to remove collection history %s", er.c.GetCollectionHistoryPathFolder())
+		internal.EXIT_CODE = 1
 	}
 }
 
@@ -73,3 +282,26 @@ func (er ExecuteRequestExecutor) HistoriseNewCollectionItem(item postman.Collect
 	}
 	return true
 }
+
+// WriteReports writes the reports "--report {format}:{path}" (junit or html) of the execution {results}.
+func (er ExecuteRequestExecutor) WriteReports(in []string, results postman.CollectionRunResults) {
+	for _, value := range internal.FindAllNextEl(in, "--report") {
+		report, err := reportutil.ParseReport(value)
+		if err != nil {
+			er.c.Print("ERROR", err.Error())
+			internal.EXIT_CODE = 1
+			continue
+		}
+		data, err := report.Build(er.c.CollectionName, results)
+		if err == nil {
+			err = iosutil.Write(data, report.Path)
+		}
+		if err != nil {
+			er.logger.Error(err, "report cannot be written", "resource", report.Path)
+			er.c.Print("ERROR", "unable to write the %s report %s", report.Format, report.Path)
+			internal.EXIT_CODE = 1
+			continue
+		}
+		er.c.Print("INFO", "%s report written to %s", report.Format, report.Path)
+	}
+}
@@ -0,0 +1,35 @@
+package promptexecutors
+
+import (
+	"github.com/joakim-ribier/gcli-4postman/internal"
+	"github.com/joakim-ribier/gcli-4postman/pkg/logger"
+	"github.com/joakim-ribier/go-utils/pkg/iosutil"
+)
+
+// Executor for export action.
+type ExportExecutor struct {
+	c      internal.Context
+	logger logger.Logger
+}
+
+// NewExportExecutor builds executor for export action.
+func NewExportExecutor(c internal.Context, logger logger.Logger) ExportExecutor {
+	return ExportExecutor{
+		c:      c,
+		logger: logger,
+	}
+}
+
+// Export writes the current collection in the Postman v2.1 format (not encrypted) to the {path} file.
+func (ee ExportExecutor) Export(path string) bool {
+	data, err := ee.c.Collection.Export()
+	if err != nil {
+		ee.logger.Error(err, "collection cannot be encoded", "collection", ee.c.CollectionName)
+		return false
+	}
+	if err := iosutil.Write(data, path); err != nil {
+		ee.logger.Error(err, "collection cannot be written", "resource", path)
+		return false
+	}
+	return true
+}
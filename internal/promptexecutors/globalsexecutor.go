@@ -0,0 +1,75 @@
+package promptexecutors
+
+import (
+	"os"
+
+	"github.com/joakim-ribier/gcli-4postman/internal"
+	"github.com/joakim-ribier/gcli-4postman/internal/pkg/prettyprint"
+	"github.com/joakim-ribier/gcli-4postman/internal/postman"
+	"github.com/joakim-ribier/gcli-4postman/internal/promptexecutors/execs"
+	"github.com/joakim-ribier/gcli-4postman/pkg/ioutil"
+	"github.com/joakim-ribier/gcli-4postman/pkg/logger"
+)
+
+// Executor for globals action.
+type GlobalsExecutor struct {
+	c      internal.Context
+	logger logger.Logger
+}
+
+// NewGlobalsExecutor builds executor for globals action.
+func NewGlobalsExecutor(c internal.Context, logger logger.Logger) GlobalsExecutor {
+	return GlobalsExecutor{
+		c:      c,
+		logger: logger,
+	}
+}
+
+// Load loads the globals of the current workspace (of the home if {home} is true), empty if the file does not exist.
+func (ge GlobalsExecutor) Load(home bool) (postman.Globals, error) {
+	path := ge.c.GetGlobalsPath(home)
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		return postman.NewGlobals(), nil
+	}
+	globals, err := ioutil.Load[postman.Globals](path, internal.SECRET)
+	if err != nil {
+		ge.logger.Error(err, "file cannot be loaded", "resource", path)
+		return postman.NewGlobals(), err
+	}
+	return globals, nil
+}
+
+// Save writes the {globals} of the current workspace (of the home if {home} is true).
+func (ge GlobalsExecutor) Save(globals postman.Globals, home bool) bool {
+	if err := ioutil.Write[postman.Globals](globals, ge.c.GetGlobalsPath(home), internal.SECRET); err != nil {
+		ge.logger.Error(err, "file cannot be written", "resource", ge.c.GetGlobalsPath(home))
+		return false
+	}
+	return true
+}
+
+// GetVariables returns the enabled globals of the current workspace then the home ones.
+func (ge GlobalsExecutor) GetVariables() postman.Variables {
+	variables := postman.Variables{}
+	for _, home := range []bool{false, true} {
+		globals, err := ge.Load(home)
+		if err != nil {
+			ge.c.Print("ERROR", "unable to load the globals '%s'", ge.c.GetGlobalsPath(home))
+			internal.EXIT_CODE = 1
+		}
+		variables = append(variables, globals.Variables()...)
+	}
+	return variables
+}
+
+// Display displays the {globals} (the values are masked if {reveal} is false).
+func (ge GlobalsExecutor) Display(globals postman.Globals, home bool, reveal bool) {
+	env := postman.Env{Name: "globals", Params: []postman.EnvParam{}}
+	if home {
+		env.Name = "home globals"
+	}
+	for _, variable := range globals.Variables() {
+		env.Params = append(env.Params, postman.EnvParam{Key: variable.Key, Value: variable.Value})
+	}
+	execs.NewDisplayEnvExec(prettyprint.Print).Display(env, reveal)
+}
@@ -0,0 +1,85 @@
+package promptexecutors
+
+import (
+	"os"
+
+	"github.com/gosimple/slug"
+	"github.com/joakim-ribier/gcli-4postman/internal"
+	"github.com/joakim-ribier/gcli-4postman/internal/pkg/httputil"
+	"github.com/joakim-ribier/gcli-4postman/internal/pkg/openapiutil"
+	"github.com/joakim-ribier/gcli-4postman/internal/postman"
+	"github.com/joakim-ribier/gcli-4postman/pkg/ioutil"
+	"github.com/joakim-ribier/gcli-4postman/pkg/logger"
+)
+
+// Executor for import action.
+type ImportExecutor struct {
+	c      internal.Context
+	logger logger.Logger
+}
+
+// NewImportExecutor builds executor for import action.
+func NewImportExecutor(c internal.Context, logger logger.Logger) ImportExecutor {
+	return ImportExecutor{
+		c:      c,
+		logger: logger,
+	}
+}
+
+// ParseOpenAPI reads and converts the OpenAPI 3.x or Swagger 2.0 (JSON or YAML) file {path} into a collection.
+func (ie ImportExecutor) ParseOpenAPI(path string) (*postman.Collection, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return openapiutil.Parse(data)
+}
+
+// ParseCurl parses the curl command {args} (a single argument is split as a shell command line) into a request.
+func (ie ImportExecutor) ParseCurl(args []string) (*postman.Item, error) {
+	if len(args) == 1 {
+		v, err := httputil.SplitCurl(args[0])
+		if err != nil {
+			return nil, err
+		}
+		args = v
+	}
+	return httputil.ParseCurl(args)
+}
+
+// AddRequest appends the {item} request to the {folder} of the current collection and writes it on the disk.
+func (ie ImportExecutor) AddRequest(folder string, item postman.Item) (*postman.Collection, bool) {
+	collection := *ie.c.Collection
+	collection.AddItem(folder, item)
+	if !ie.SaveCollection(collection, ie.c.WorkspaceName, ie.c.CollectionName) {
+		return nil, false
+	}
+	return &collection, true
+}
+
+// GetCollectionName returns the file name of the imported {collection} ({name} if provided).
+func (ie ImportExecutor) GetCollectionName(collection postman.Collection, name string) string {
+	if name != "" {
+		return slug.Make(name)
+	}
+	return slug.Make(collection.Info.Name)
+}
+
+// GetCollectionPath returns the path of the {name} collection in the {workspace}.
+func (ie ImportExecutor) GetCollectionPath(workspace, name string) string {
+	return internal.GetHomeWorkspaceFilePath(workspace, name+".collection.json")
+}
+
+// SaveCollection writes the {collection} in the {workspace} (created if it does not exist) as {name}.collection.json.
+func (ie ImportExecutor) SaveCollection(collection postman.Collection, workspace, name string) bool {
+	if err := os.MkdirAll(internal.GetHomeWorkspacePath(workspace), os.ModePerm); err != nil {
+		ie.logger.Error(err, "folder cannot be created", "resource", internal.GetHomeWorkspacePath(workspace))
+		return false
+	}
+	path := ie.GetCollectionPath(workspace, name)
+	if err := ioutil.Write[postman.Collection](collection, path, internal.SECRET); err != nil {
+		ie.logger.Error(err, "collection cannot be written", "resource", path)
+		return false
+	}
+	return true
+}
@@ -0,0 +1,152 @@
+package promptexecutors
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/joakim-ribier/gcli-4postman/internal"
+	"github.com/joakim-ribier/gcli-4postman/internal/pkg/prettyprint"
+	"github.com/joakim-ribier/gcli-4postman/internal/postman"
+	"github.com/joakim-ribier/gcli-4postman/internal/promptexecutors/execs"
+	"github.com/joakim-ribier/gcli-4postman/pkg/logger"
+	"github.com/joakim-ribier/go-utils/pkg/slicesutil"
+)
+
+// Executor for run collection action.
+type RunCollectionExecutor struct {
+	c      internal.Context
+	logger logger.Logger
+	output func(string)
+}
+
+// NewRunCollectionExecutor builds executor for run collection action.
+func NewRunCollectionExecutor(c internal.Context, logger logger.Logger, output func(string)) RunCollectionExecutor {
+	return RunCollectionExecutor{
+		c:      c,
+		logger: logger,
+		output: output,
+	}
+}
+
+// FindRequests finds (in the collection order) the requests of the folder "-f {folder}" which match with "--search {pattern}".
+func (rc RunCollectionExecutor) FindRequests(in []string) postman.Items {
+	return rc.c.Collection.GetRequests(slicesutil.FindNextEl(in, "-f"), slicesutil.FindNextEl(in, "--search"))
+}
+
+// Run executes the {requests} in order for each iteration ("--data {file}" and "--iterations {n}"),
+// the env and the collection variables (in memory only) updated by a request are used by the next ones
+// and each response is historised.
+//
+// {requests} is called again before each call to compute the requests with the collection variables set by the previous ones.
+func (rc RunCollectionExecutor) Run(in []string, requests func() postman.Items) postman.CollectionRunResults {
+	items := requests()
+	if len(items) == 0 {
+		rc.c.Print("WARN", "no request to run")
+		internal.EXIT_CODE = 1
+		return nil
+	}
+
+	rows, err := rc.loadIterationData(slicesutil.FindNextEl(in, "--data"))
+	if err != nil {
+		rc.logger.Error(err, "data file cannot be loaded", "resource", slicesutil.FindNextEl(in, "--data"))
+		rc.c.Print("ERROR", "unable to load the data file: %s", err.Error())
+		internal.EXIT_CODE = 1
+		return nil
+	}
+
+	iterations := max(len(rows), 1)
+	if v, err := strconv.Atoi(slicesutil.FindNextEl(in, "--iterations")); err == nil && v > 0 {
+		iterations = v
+	}
+
+	delay, _ := strconv.Atoi(slicesutil.FindNextEl(in, "--delay"))
+
+	c := rc.c
+	results := postman.CollectionRunResults{}
+run:
+	for iteration := 1; iteration <= iterations; iteration++ {
+		var data postman.IterationData
+		if len(rows) > 0 {
+			// the last row is reused if there are more iterations than rows
+			data = rows[min(iteration, len(rows))-1]
+		}
+
+		for i := range items {
+			if len(results) > 0 && delay > 0 {
+				time.Sleep(time.Duration(delay) * time.Millisecond)
+			}
+
+			item := requests()[i]
+			result := postman.CollectionRunResult{Label: item.GetLabel(), Iteration: iteration}
+			executor := NewExecuteRequestExecutor(c, rc.logger)
+			if response, err := executor.Call(in, item, data); err != nil {
+				result.Error = err.Error()
+			} else {
+				result.Response = response
+				executor.HistoriseNewCollectionItem(*response)
+
+				// refresh the local context for the next requests
+				c.CollectionHistoryRequests = append(c.CollectionHistoryRequests, response.ToLight())
+				if response.Env != nil && response.Env.Name != "" {
+					c.UpdateEnv(*response.Env)
+				}
+			}
+			rc.display(i+1, len(items), iterations, result)
+			results = append(results, result)
+
+			if result.HasFailed() && slicesutil.Exist(in, "--bail") {
+				rc.c.Print("WARN", "run stopped on the first failure (--bail)")
+				break run
+			}
+		}
+	}
+
+	execs.NewDisplayRunSummaryExec(rc.output).Display(results)
+	NewExecuteRequestExecutor(rc.c, rc.logger).WriteReports(in, results)
+	if results.HasFailed() {
+		internal.EXIT_CODE = 1
+	}
+	return results
+}
+
+// loadIterationData loads the CSV or JSON (".json" extension) data file {path}.
+func (rc RunCollectionExecutor) loadIterationData(path string) ([]postman.IterationData, error) {
+	if path == "" {
+		return nil, nil
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return postman.ParseIterationData(content, strings.EqualFold(filepath.Ext(path), ".json"))
+}
+
+// display displays the {result} of the request {number} on {total}.
+func (rc RunCollectionExecutor) display(number, total, iterations int, result postman.CollectionRunResult) {
+	prefix := fmt.Sprintf("%d/%d", number, total)
+	if !internal.IsTextOutput() {
+		// the results are output as structured documents by the summary
+		return
+	}
+	if iterations > 1 {
+		prefix = fmt.Sprintf("[%d/%d] %s", result.Iteration, iterations, prefix)
+	}
+	state := prettyprint.FormatTextWithColor("[PASS]", "G", false)
+	if result.HasFailed() {
+		state = prettyprint.FormatTextWithColor("[FAIL]", "R", false)
+	}
+	if result.Response == nil {
+		rc.output(fmt.Sprintf("%s %s %s %s", prefix, state, result.Label, result.Error))
+		return
+	}
+	rc.output(fmt.Sprintf("%s %s %s STATUS=%s TIME_(ms)=%d", prefix, state, result.Label, result.Response.Status, result.Response.TimeInMillis))
+	for _, test := range result.Response.TestResults {
+		if !test.Passed {
+			rc.output(fmt.Sprintf("    %s %s", prettyprint.FormatTextWithColor(test.Name, "R", false), test.Error))
+		}
+	}
+}
@@ -3,6 +3,7 @@ package promptexecutors
 import (
 	"github.com/joakim-ribier/gcli-4postman/internal"
 	"github.com/joakim-ribier/gcli-4postman/internal/promptexecutors/execs"
+	"github.com/joakim-ribier/gcli-4postman/pkg/ioutil"
 	"github.com/joakim-ribier/gcli-4postman/pkg/logger"
 )
 
@@ -34,3 +35,19 @@ func (s SettingsExecutor) EnableSecureMode(secret string) bool {
 func (s SettingsExecutor) DisableSecureMode() bool {
 	return execs.NewSecureModeExec(s.c, s.logger).Decrypt()
 }
+
+// SaveSettings writes the application {settings} on the disk.
+func (s SettingsExecutor) SaveSettings(settings internal.Settings) bool {
+	if err := ioutil.Write[internal.Settings](settings, s.c.GetSettingsPath(), internal.SECRET); err != nil {
+		s.logger.Error(err, "file cannot be written", "resource", s.c.GetSettingsPath())
+		s.c.Print("ERROR", "unable to save the settings")
+		internal.EXIT_CODE = 1
+		return false
+	}
+	return true
+}
+
+// DisplayHttpSettings displays the current HTTP client settings.
+func (s SettingsExecutor) DisplayHttpSettings() {
+	execs.NewDisplayHttpSettingsExec(s.c.Print).Display(s.c.Settings.Http)
+}
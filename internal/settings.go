@@ -0,0 +1,10 @@
+package internal
+
+import (
+	"github.com/joakim-ribier/gcli-4postman/internal/pkg/httputil"
+)
+
+// Settings are the application settings persisted in the $GCLI_4POSTMAN_HOME folder.
+type Settings struct {
+	Http httputil.Options
+}